@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/golang/glog"
@@ -36,15 +37,39 @@ type AutoScalerConfig struct {
 	PollPeriodSeconds int
 	PrintVer          bool
 	NodeLabels        string
+	TopologyKey       string
 	MaxSyncFailures   int
+	ExtendedResources []string
+	CountPods         bool
+	PodNamespace      string
+	PodLabels         string
+	CountServices     bool
+
+	CountRequests                bool
+	RequestsExcludeDaemonSetPods bool
+	RequestsExcludeNamespaces    []string
+
+	PrometheusAddress        string
+	PrometheusTimeoutSeconds int
+	PrometheusAuthHeaderFile string
+
+	HPA                      string
+	HPAMaxReplicasMultiplier float64
+
+	PDBMinAvailableRatio   float64
+	PDBMaxUnavailableRatio float64
+
+	OutputConfigMap string
 }
 
 // NewAutoScalerConfig returns a Autoscaler config
 func NewAutoScalerConfig() *AutoScalerConfig {
 	return &AutoScalerConfig{
-		Namespace:         os.Getenv("MY_POD_NAMESPACE"),
-		PollPeriodSeconds: 10,
-		PrintVer:          false,
+		Namespace:                os.Getenv("MY_POD_NAMESPACE"),
+		PollPeriodSeconds:        10,
+		PrintVer:                 false,
+		PrometheusTimeoutSeconds: 10,
+		TopologyKey:              "topology.kubernetes.io/zone",
 	}
 }
 
@@ -52,7 +77,8 @@ func NewAutoScalerConfig() *AutoScalerConfig {
 func (c *AutoScalerConfig) ValidateFlags() error {
 	var errorsFound bool
 	c.Target = strings.ToLower(c.Target)
-	if !isTargetFormatValid(c.Target) {
+	// Targets are optional when the expected replicas are only published
+	if (c.OutputConfigMap == "" || c.Target != "") && !isTargetFormatValid(c.Target) {
 		errorsFound = true
 	}
 	if c.ConfigMap == "" {
@@ -67,6 +93,67 @@ func (c *AutoScalerConfig) ValidateFlags() error {
 		errorsFound = true
 		glog.Errorf("--poll-period-seconds cannot be less than 1")
 	}
+	if !c.CountPods && (c.PodNamespace != "" || c.PodLabels != "") {
+		errorsFound = true
+		glog.Errorf("--pod-namespace and --pod-labels require --count-pods to be set")
+	}
+	if !c.CountRequests && (c.RequestsExcludeDaemonSetPods || len(c.RequestsExcludeNamespaces) > 0) {
+		errorsFound = true
+		glog.Errorf("--requests-exclude-daemonset-pods and --requests-exclude-namespaces require --count-requests to be set")
+	}
+	if c.PrometheusTimeoutSeconds < 1 {
+		errorsFound = true
+		glog.Errorf("--prometheus-timeout-seconds cannot be less than 1")
+	}
+	if c.PrometheusAddress == "" && c.PrometheusAuthHeaderFile != "" {
+		errorsFound = true
+		glog.Errorf("--prometheus-auth-header-file requires --prometheus-address to be set")
+	}
+	if c.HPAMaxReplicasMultiplier != 0 && c.HPAMaxReplicasMultiplier < 1 {
+		errorsFound = true
+		glog.Errorf("--hpa-max-replicas-multiplier cannot be less than 1")
+	}
+	if c.HPA == "" && c.HPAMaxReplicasMultiplier != 0 {
+		errorsFound = true
+		glog.Errorf("--hpa-max-replicas-multiplier requires --hpa to be set")
+	}
+	if c.PDBMinAvailableRatio < 0 || c.PDBMinAvailableRatio >= 1 {
+		errorsFound = true
+		glog.Errorf("--pdb-min-available-ratio should be between 0 and 1")
+	}
+	if c.PDBMaxUnavailableRatio < 0 || c.PDBMaxUnavailableRatio > 1 {
+		errorsFound = true
+		glog.Errorf("--pdb-max-unavailable-ratio should be between 0 and 1")
+	}
+	if c.PDBMinAvailableRatio != 0 && c.PDBMaxUnavailableRatio != 0 {
+		errorsFound = true
+		glog.Errorf("--pdb-min-available-ratio and --pdb-max-unavailable-ratio cannot be both set")
+	}
+	if c.OutputConfigMap != "" && (c.HPA != "" || c.PDBMinAvailableRatio != 0 || c.PDBMaxUnavailableRatio != 0) {
+		errorsFound = true
+		glog.Errorf("--output-configmap cannot be set along with --hpa, --pdb-min-available-ratio or --pdb-max-unavailable-ratio")
+	}
+	if strings.Contains(c.Target, "@") {
+		if c.TopologyKey == "" {
+			errorsFound = true
+			glog.Errorf("zonal targets require --topology-key to be set")
+		}
+		if c.HPA != "" || c.OutputConfigMap != "" {
+			errorsFound = true
+			glog.Errorf("zonal targets cannot be set along with --hpa or --output-configmap")
+		}
+	}
+	if c.OutputConfigMap != "" && c.OutputConfigMap == c.ConfigMap {
+		errorsFound = true
+		glog.Errorf("--output-configmap cannot be the same as --configmap")
+	}
+	for _, name := range c.ExtendedResources {
+		if strings.TrimSpace(name) == "" {
+			errorsFound = true
+			glog.Errorf("--extended-resources cannot contain empty resource names")
+			break
+		}
+	}
 
 	// Log all sanity check errors before returning a single error string
 	if errorsFound {
@@ -81,9 +168,28 @@ func isTargetFormatValid(target string) bool {
 		return false
 	}
 
+	weighted := strings.Contains(target, "=")
+	zonal := strings.Contains(target, "@")
+	if weighted && zonal {
+		glog.Errorf("Target format error. Zonal targets cannot be weighted.")
+		return false
+	}
 	for _, target := range strings.Split(target, ",") {
-		target := strings.TrimSpace(target)
+		target, weight, hasWeight := strings.Cut(strings.TrimSpace(target), "=")
+		target, domain, hasDomain := strings.Cut(target, "@")
 
+		if hasWeight != weighted {
+			glog.Errorf("Target format error. Either all or none of the targets should be weighted.")
+			return false
+		}
+		if hasDomain != zonal || (hasDomain && domain == "") {
+			glog.Errorf("Target format error. Either all or none of the targets should be zonal, as '<kind>/<name>@<topology domain>', e.g. 'deployment/dns-a@us-east-1a,deployment/dns-b@us-east-1b'.")
+			return false
+		}
+		if hasWeight && !isWeightFormatValid(weight) {
+			glog.Errorf("Target weight format error. Please use '<kind>/<name>=<weight>[:<min replicas>]' with non-negative integers, e.g. 'deployment/dns-amd64=3,deployment/dns-arm64=1:2'.")
+			return false
+		}
 		if !strings.HasPrefix(target, "deployment/") &&
 			!strings.HasPrefix(target, "replicationcontroller/") &&
 			!strings.HasPrefix(target, "replicaset/") {
@@ -95,6 +201,19 @@ func isTargetFormatValid(target string) bool {
 	return true
 }
 
+func isWeightFormatValid(weight string) bool {
+	weight, minReplicas, hasMin := strings.Cut(weight, ":")
+	if _, err := strconv.ParseUint(weight, 10, 31); err != nil {
+		return false
+	}
+	if hasMin {
+		if _, err := strconv.ParseUint(minReplicas, 10, 31); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 type configMapData map[string]string
 
 func (c *configMapData) Set(raw string) error {
@@ -123,12 +242,29 @@ func (c *configMapData) Type() string {
 
 // AddFlags adds flags for a specific AutoScaler to the specified FlagSet
 func (c *AutoScalerConfig) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&c.Target, "target", c.Target, "Target to scale. In format: 'deployment/*,replicationcontroller/*,replicaset/*' (not case sensitive, comma delimiter supported).")
+	fs.StringVar(&c.Target, "target", c.Target, "Target to scale. In format: 'deployment/*,replicationcontroller/*,replicaset/*' (not case sensitive, comma delimiter supported). Targets could be weighted as 'deployment/*=<weight>[:<min replicas>]' to split the replicas across them, or zonal as 'deployment/*@<topology domain>' to scale each of them from the nodes of its topology domain.")
 	fs.StringVar(&c.ConfigMap, "configmap", c.ConfigMap, "ConfigMap containing our scaling parameters.")
 	fs.StringVar(&c.Namespace, "namespace", c.Namespace, "Namespace for all operations, fallback to the namespace of this autoscaler(through MY_POD_NAMESPACE env) if not specified.")
 	fs.IntVar(&c.PollPeriodSeconds, "poll-period-seconds", c.PollPeriodSeconds, "The time, in seconds, to check cluster status and perform autoscale.")
 	fs.BoolVar(&c.PrintVer, "version", c.PrintVer, "Print the version and exit.")
 	fs.Var(&c.DefaultParams, "default-params", "Default parameters(JSON format) for auto-scaling. Will create/re-create a ConfigMap with this default params if ConfigMap is not present.")
 	fs.StringVar(&c.NodeLabels, "nodelabels", c.NodeLabels, "NodeLabels for filtering search of nodes and its cpus by LabelSelectors. Input format is a comma separated list of keyN=valueN LabelSelectors. Usage example: --nodelabels=label1=value1,label2=value2.")
+	fs.StringVar(&c.TopologyKey, "topology-key", c.TopologyKey, "Label of the nodes bucketing them into topology domains, e.g. zones or racks, from which zonal targets are scaled. Its domains may also be counted by minReplicasPerZone.")
 	fs.IntVar(&c.MaxSyncFailures, "max-sync-failures", c.MaxSyncFailures, "Number of consecutive polling failures before exiting. Default value of 0 will allow for unlimited retries.")
+	fs.BoolVar(&c.CountPods, "count-pods", c.CountPods, "Count the non-terminal pods in the cluster, which could then be used as a scaling input. Requires permissions to list and watch pods.")
+	fs.StringVar(&c.PodNamespace, "pod-namespace", c.PodNamespace, "Namespace of the pods to count when --count-pods is set. Pods from all namespaces are counted if not specified.")
+	fs.StringVar(&c.PodLabels, "pod-labels", c.PodLabels, "PodLabels for filtering the pods to count by LabelSelectors when --count-pods is set. Usage example: --pod-labels=label1=value1,label2=value2.")
+	fs.BoolVar(&c.CountServices, "count-services", c.CountServices, "Count the services and the endpoints of all endpoint slices in the cluster, which could then be used as scaling inputs. Requires permissions to list and watch services and endpointslices.")
+	fs.BoolVar(&c.CountRequests, "count-requests", c.CountRequests, "Sum the cpu and memory requests of the scheduled non-terminal pods in the cluster, which could then be used as scaling inputs. Requires permissions to list and watch pods.")
+	fs.BoolVar(&c.RequestsExcludeDaemonSetPods, "requests-exclude-daemonset-pods", c.RequestsExcludeDaemonSetPods, "Leave out the requests of the pods controlled by a DaemonSet when --count-requests is set.")
+	fs.StringSliceVar(&c.RequestsExcludeNamespaces, "requests-exclude-namespaces", c.RequestsExcludeNamespaces, "Namespaces whose pods are left out of the requests when --count-requests is set. Usage example: --requests-exclude-namespaces=kube-system,monitoring.")
+	fs.StringVar(&c.PrometheusAddress, "prometheus-address", c.PrometheusAddress, "Address of the Prometheus HTTP API to query for prometheus signals, e.g. http://prometheus.monitoring:9090.")
+	fs.IntVar(&c.PrometheusTimeoutSeconds, "prometheus-timeout-seconds", c.PrometheusTimeoutSeconds, "The time, in seconds, to wait for each Prometheus query.")
+	fs.StringVar(&c.PrometheusAuthHeaderFile, "prometheus-auth-header-file", c.PrometheusAuthHeaderFile, "File containing the value of the Authorization header sent to Prometheus, e.g. 'Bearer <token>'. The file is read before each query.")
+	fs.StringVar(&c.HPA, "hpa", c.HPA, "HorizontalPodAutoscaler, in the namespace of the targets, whose minReplicas is set to the expected replicas instead of scaling the targets directly. Requires permissions to get and patch horizontalpodautoscalers.")
+	fs.Float64Var(&c.HPAMaxReplicasMultiplier, "hpa-max-replicas-multiplier", c.HPAMaxReplicasMultiplier, "Set the maxReplicas of the HorizontalPodAutoscaler given by --hpa to the expected replicas times this multiplier, rounded up. The maxReplicas is left as is if not specified.")
+	fs.Float64Var(&c.PDBMinAvailableRatio, "pdb-min-available-ratio", c.PDBMinAvailableRatio, "Create and update a PodDisruptionBudget for each target, with minAvailable set to the expected replicas times this ratio, rounded down. Requires permissions to get, create and update poddisruptionbudgets.")
+	fs.Float64Var(&c.PDBMaxUnavailableRatio, "pdb-max-unavailable-ratio", c.PDBMaxUnavailableRatio, "Create and update a PodDisruptionBudget for each target, with maxUnavailable set to the expected replicas times this ratio, rounded up. Requires permissions to get, create and update poddisruptionbudgets.")
+	fs.StringVar(&c.OutputConfigMap, "output-configmap", c.OutputConfigMap, "ConfigMap, in the namespace given by --namespace, to publish the expected replicas and the scaling inputs into instead of scaling the targets, which are then optional. Requires permissions to get, create and update the ConfigMap.")
+	fs.StringSliceVar(&c.ExtendedResources, "extended-resources", c.ExtendedResources, "Extended resources (e.g. nvidia.com/gpu) to count from the allocatable resources of the nodes, in addition to cores and memory. Usage example: --extended-resources=nvidia.com/gpu,example.com/fpga.")
 }
@@ -46,6 +46,42 @@ func TestIsTargetFormatValid(t *testing.T) {
 			"DeplOymEnT/anything, replicaset/anything,replicationcontroller/anything",
 			true,
 		},
+		{
+			"deployment/dns-amd64=3,deployment/dns-arm64=1:2",
+			true,
+		},
+		{
+			"deployment/stable=0:1, deployment/canary=1",
+			true,
+		},
+		{
+			"deployment/stable=9,deployment/canary",
+			false,
+		},
+		{
+			"deployment/stable=-1,deployment/canary=1",
+			false,
+		},
+		{
+			"deployment/stable=1:,deployment/canary=1",
+			false,
+		},
+		{
+			"deployment/dns-a@us-east-1a,deployment/dns-b@us-east-1b",
+			true,
+		},
+		{
+			"deployment/dns-a@us-east-1a,deployment/dns-b",
+			false,
+		},
+		{
+			"deployment/dns-a@,deployment/dns-b@us-east-1b",
+			false,
+		},
+		{
+			"deployment/dns-a@us-east-1a=1,deployment/dns-b@us-east-1b=1",
+			false,
+		},
 		{
 			"deployments/anything",
 			false,
@@ -17,10 +17,18 @@ limitations under the License.
 package autoscaler
 
 import (
+	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
@@ -33,9 +41,22 @@ import (
 	"github.com/golang/glog"
 )
 
+const (
+	// outputValueKey holds the expected replicas in the output ConfigMap, and
+	// outputInputKeyPrefix prefixes the signals they were computed from.
+	outputValueKey       = "value"
+	outputInputKeyPrefix = "input."
+)
+
 // AutoScaler determines the number of replicas to run
 type AutoScaler struct {
 	k8sClient           k8sclient.K8sClient
+	objectCounter       k8sclient.ObjectCounter
+	clusterSignals      *clusterSignalProvider
+	prometheus          *prometheusSignalProvider
+	metrics             *metricSignalProvider
+	signalProviders     signalRegistry
+	signalFallbacks     map[string][]string
 	controller          controller.Controller
 	configMapName       string
 	defaultParams       map[string]string
@@ -47,6 +68,25 @@ type AutoScaler struct {
 	lastPollCycleHealth *healthInfo
 	maxSyncFailures     int
 	exitFn              func()
+
+	// hpaName is the HorizontalPodAutoscaler whose replicas are updated
+	// instead of the replicas of the targets, if set.
+	hpaName                  string
+	hpaMaxReplicasMultiplier float64
+	// pdbMinAvailableRatio or pdbMaxUnavailableRatio enables the
+	// PodDisruptionBudgets of the targets.
+	pdbMinAvailableRatio   float64
+	pdbMaxUnavailableRatio float64
+	// outputConfigMap is the ConfigMap the expected replicas are published
+	// into instead of scaling the targets, if set.
+	outputConfigMap string
+
+	// targetControllers holds the controller of each target, keyed by
+	// kind/name, when the ConfigMap carries per-target params.
+	targetControllers map[string]controller.Controller
+	// targetParamsVersion is the version of the ConfigMap the per-target
+	// controllers were last ensured from.
+	targetParamsVersion string
 }
 
 // NewAutoScaler returns a new AutoScaler
@@ -61,14 +101,41 @@ func NewAutoScaler(c *options.AutoScalerConfig) (*AutoScaler, error) {
 	if err != nil {
 		return nil, err
 	}
-	newK8sClient, err := k8sclient.NewK8sClient(clientset, c.Namespace, c.Target, c.NodeLabels)
+	var podCounterOptions *k8sclient.PodCounterOptions
+	if c.CountPods {
+		podCounterOptions = &k8sclient.PodCounterOptions{
+			Namespace:     c.PodNamespace,
+			LabelSelector: c.PodLabels,
+		}
+	}
+	var requestCounterOptions *k8sclient.RequestCounterOptions
+	if c.CountRequests {
+		requestCounterOptions = &k8sclient.RequestCounterOptions{
+			ExcludeDaemonSetPods: c.RequestsExcludeDaemonSetPods,
+			ExcludeNamespaces:    c.RequestsExcludeNamespaces,
+		}
+	}
+	newK8sClient, err := k8sclient.NewK8sClient(clientset, c.Namespace, c.Target, c.NodeLabels, c.TopologyKey, c.ExtendedResources, podCounterOptions, requestCounterOptions, c.CountServices)
+	if err != nil {
+		return nil, err
+	}
+	// The dynamic client only supports JSON, it overrides the content type.
+	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
 	healthInfo := newHealthInfo()
 	healthServer := httpHealthServer{lastPollCycleHealth: healthInfo}
-	return &AutoScaler{
+	metricsClient, err := k8sclient.NewMetricsClient(config, clientset.Discovery(), newK8sClient.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	objectCounter := k8sclient.NewObjectCounter(dynamicClient)
+	autoScaler := &AutoScaler{
 		k8sClient:           newK8sClient,
+		objectCounter:       objectCounter,
+		clusterSignals:      &clusterSignalProvider{},
+		metrics:             &metricSignalProvider{metricsClient: metricsClient},
 		configMapName:       c.ConfigMap,
 		defaultParams:       c.DefaultParams,
 		pollPeriod:          time.Second * time.Duration(c.PollPeriodSeconds),
@@ -79,7 +146,36 @@ func NewAutoScaler(c *options.AutoScalerConfig) (*AutoScaler, error) {
 		healthServer:        &healthServer,
 		maxSyncFailures:     c.MaxSyncFailures,
 		exitFn:              func() { os.Exit(1) },
-	}, nil
+
+		hpaName:                  c.HPA,
+		hpaMaxReplicasMultiplier: c.HPAMaxReplicasMultiplier,
+		pdbMinAvailableRatio:     c.PDBMinAvailableRatio,
+		pdbMaxUnavailableRatio:   c.PDBMaxUnavailableRatio,
+		outputConfigMap:          c.OutputConfigMap,
+	}
+	if err := autoScaler.RegisterSignalProvider(ClusterSignalProvider, autoScaler.clusterSignals); err != nil {
+		return nil, err
+	}
+	if err := autoScaler.RegisterSignalProvider(ObjectsSignalProvider, &objectSignalProvider{objectCounter: objectCounter}); err != nil {
+		return nil, err
+	}
+	if err := autoScaler.RegisterSignalProvider(MetricsSignalProvider, autoScaler.metrics); err != nil {
+		return nil, err
+	}
+	if c.PrometheusAddress != "" {
+		autoScaler.prometheus = newPrometheusSignalProvider(c.PrometheusAddress, time.Second*time.Duration(c.PrometheusTimeoutSeconds), c.PrometheusAuthHeaderFile)
+		if err := autoScaler.RegisterSignalProvider(PrometheusSignalProvider, autoScaler.prometheus); err != nil {
+			return nil, err
+		}
+	}
+	return autoScaler, nil
+}
+
+// RegisterSignalProvider registers an additional provider of named signals,
+// which could then be used as scaling inputs. The signals of a provider which
+// fails fall back as declared in the "fallbacks" section of the params.
+func (s *AutoScaler) RegisterSignalProvider(name string, provider SignalProvider) error {
+	return s.signalProviders.register(name, provider)
 }
 
 // Run periodically counts the number of nodes and cores, estimates the expected
@@ -122,6 +218,13 @@ func (s *AutoScaler) pollAPIServer() error {
 	}
 	glog.V(4).Infof("Total nodes %5d, schedulable nodes: %5d", clusterStatus.TotalNodes, clusterStatus.SchedulableNodes)
 	glog.V(4).Infof("Total cores %5d, schedulable cores: %5d", clusterStatus.TotalCores, clusterStatus.SchedulableCores)
+	glog.V(4).Infof("Total memory %d, schedulable memory: %d", clusterStatus.TotalMemory, clusterStatus.SchedulableMemory)
+	glog.V(4).Infof("Total pods %5d", clusterStatus.TotalPods)
+	glog.V(4).Infof("Requested cores %v, requested memory: %d", clusterStatus.RequestedCores, clusterStatus.RequestedMemory)
+	glog.V(4).Infof("Total services %5d, total endpoints: %5d", clusterStatus.TotalServices, clusterStatus.TotalEndpoints)
+	for name, total := range clusterStatus.TotalExtendedResources {
+		glog.V(4).Infof("Total %s %d, schedulable %s: %d", name, total, name, clusterStatus.SchedulableExtendedResources[name])
+	}
 
 	// Sync autoscaler ConfigMap with apiserver
 	configMap, err := s.syncConfigWithServer()
@@ -130,26 +233,187 @@ func (s *AutoScaler) pollAPIServer() error {
 		return err
 	}
 
-	// Only sync updated ConfigMap or before controller is set.
-	if s.controller == nil || configMap.ObjectMeta.ResourceVersion != s.controller.GetParamsVersion() {
+	if targetParams, ok := configMap.Data[perTargetKey]; ok {
+		err = s.ensureTargetControllers(configMap, targetParams)
+		if err != nil {
+			glog.Errorf("Error ensuring per-target controllers: %v", err)
+			return err
+		}
+	} else if s.controller == nil || configMap.ObjectMeta.ResourceVersion != s.controller.GetParamsVersion() {
+		// Only sync updated ConfigMap or before controller is set.
+		// Ensure the declared signals are counted before the controller refers to them.
+		if err := s.syncSignals(configMap); err != nil {
+			glog.Errorf("Error syncing signals: %v", err)
+			return err
+		}
 		// Ensure corresponding controller type and scaling params.
-		s.controller, err = plugin.EnsureController(s.controller, configMap)
+		s.controller, err = plugin.EnsureController(s.controller, configMap, s.k8sClient)
 		if err != nil || s.controller == nil {
 			glog.Errorf("Error ensuring controller: %v", err)
 			return err
 		}
+		s.targetControllers = nil
+	}
+
+	// Collect signals from all providers, a failing provider only fails the
+	// cycle if a signal it provides is used and has no available fallback.
+	if s.clusterSignals != nil {
+		s.clusterSignals.status = clusterStatus
+	}
+	clusterStatus.Signals = s.signalProviders.collect()
+	applyFallbacks(clusterStatus.Signals, s.signalFallbacks)
+	for name, value := range clusterStatus.Signals {
+		glog.V(4).Infof("Signal %s: %v", name, value)
+	}
+
+	if s.targetControllers != nil || s.k8sClient.GetTargetTopologyDomains() != nil {
+		return s.scaleEachTarget(clusterStatus)
+	}
+	return s.scale(s.controller, s.k8sClient, clusterStatus)
+}
+
+// scale applies the result of the controller to the targets of k8sClient.
+func (s *AutoScaler) scale(cont controller.Controller, k8sClient k8sclient.K8sClient, clusterStatus *k8sclient.ClusterStatus) error {
+	// Resizing controllers set the resources of a container of the target
+	// rather than its replicas.
+	if resizer, ok := cont.(controller.Resizer); ok {
+		return s.resize(resizer, k8sClient, clusterStatus)
 	}
 
 	// Query the controller for the expected replicas number
-	expReplicas, err := s.controller.GetExpectedReplicas(clusterStatus)
+	expReplicas, err := cont.GetExpectedReplicas(clusterStatus)
 	if err != nil {
 		glog.Errorf("Error calculating expected replicas number: %v", err)
 		return err
 	}
 	glog.V(4).Infof("Expected replica count: %3d", expReplicas)
 
-	// Update resource target with expected replicas.
-	err = s.k8sClient.UpdateReplicas(expReplicas)
+	if s.outputConfigMap != "" {
+		err = s.publish(expReplicas, clusterStatus)
+		if err != nil {
+			glog.Errorf("Publish failure: %s", err)
+		}
+		return err
+	}
+	if s.hpaName != "" {
+		err = s.updateHPA(expReplicas)
+	} else if err = s.checkNoTargetHPA(k8sClient); err == nil {
+		// Update resource target with expected replicas.
+		err = k8sClient.UpdateReplicas(expReplicas)
+	}
+	if err != nil {
+		glog.Errorf("Update failure: %s", err)
+		return err
+	}
+
+	err = s.updatePDBs(k8sClient, expReplicas)
+	if err != nil {
+		glog.Errorf("PodDisruptionBudget update failure: %s", err)
+	}
+	return err
+}
+
+// publish writes the expected replicas and the signals they were computed from
+// into the output ConfigMap, creating it if needed. It is only updated when
+// the expected replicas change, so that applications watching it are not
+// notified of every change of the inputs. Other keys of the ConfigMap are left
+// as is, except for inputs which are no longer published.
+func (s *AutoScaler) publish(expReplicas int32, clusterStatus *k8sclient.ClusterStatus) error {
+	value := strconv.Itoa(int(expReplicas))
+	namespace := s.k8sClient.GetNamespace()
+	configMap, err := s.k8sClient.FetchConfigMap(namespace, s.outputConfigMap)
+	if apierrors.IsNotFound(err) {
+		_, err = s.k8sClient.CreateConfigMap(namespace, s.outputConfigMap, publishedData(nil, value, clusterStatus.Signals))
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.Data[outputValueKey] == value {
+		return nil
+	}
+	glog.V(0).Infof("Published value is not as expected : updating ConfigMap %s from %q to %q",
+		s.outputConfigMap,
+		configMap.Data[outputValueKey],
+		value)
+	_, err = s.k8sClient.UpdateConfigMapData(configMap, publishedData(configMap.Data, value, clusterStatus.Signals))
+	return err
+}
+
+// publishedData returns a copy of data with the value and the inputs replaced.
+func publishedData(data map[string]string, value string, signals map[string]float64) map[string]string {
+	published := make(map[string]string, len(data)+len(signals)+1)
+	for key, v := range data {
+		if !strings.HasPrefix(key, outputInputKeyPrefix) {
+			published[key] = v
+		}
+	}
+	published[outputValueKey] = value
+	for name, signal := range signals {
+		key := outputInputKeyPrefix + name
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			glog.V(4).Infof("Not publishing signal %s: %v", name, errs)
+			continue
+		}
+		published[key] = strconv.FormatFloat(signal, 'f', -1, 64)
+	}
+	return published
+}
+
+// updateHPA sets the expected replicas as the minReplicas of the
+// HorizontalPodAutoscaler, which scales the targets on top of them.
+func (s *AutoScaler) updateHPA(expReplicas int32) error {
+	var maxReplicas int32
+	if s.hpaMaxReplicasMultiplier != 0 {
+		maxReplicas = int32(math.Min(math.Ceil(float64(expReplicas)*s.hpaMaxReplicasMultiplier), math.MaxInt32))
+	}
+	return s.k8sClient.UpdateHPAReplicas(s.hpaName, expReplicas, maxReplicas)
+}
+
+// updatePDBs reconciles the PodDisruptionBudgets of the targets with their
+// replicas, if enabled. Either way of rounding leaves room for a disruption as
+// long as there is a replica.
+func (s *AutoScaler) updatePDBs(k8sClient k8sclient.K8sClient, expReplicas int32) error {
+	if s.pdbMinAvailableRatio == 0 && s.pdbMaxUnavailableRatio == 0 {
+		return nil
+	}
+	return k8sClient.UpdatePodDisruptionBudgets(expReplicas, func(replicas int32) (minAvailable, maxUnavailable *intstr.IntOrString) {
+		if s.pdbMinAvailableRatio != 0 {
+			value := intstr.FromInt32(int32(math.Floor(controller.RoundOff(float64(replicas) * s.pdbMinAvailableRatio))))
+			return &value, nil
+		}
+		value := intstr.FromInt32(int32(math.Ceil(controller.RoundOff(float64(replicas) * s.pdbMaxUnavailableRatio))))
+		return nil, &value
+	})
+}
+
+// checkNoTargetHPA returns an error if a HorizontalPodAutoscaler scales any of
+// the targets. The check is skipped if listing them is forbidden.
+func (s *AutoScaler) checkNoTargetHPA(k8sClient k8sclient.K8sClient) error {
+	hpas, err := k8sClient.GetTargetHPAs()
+	if apierrors.IsForbidden(err) {
+		glog.V(2).Infof("Skipping the check for HorizontalPodAutoscalers scaling the targets: %v", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(hpas) > 0 {
+		return fmt.Errorf("refusing to scale the targets, which are also scaled by HorizontalPodAutoscaler %v: use --hpa to set its minReplicas instead", hpas)
+	}
+	return nil
+}
+
+func (s *AutoScaler) resize(resizer controller.Resizer, k8sClient k8sclient.K8sClient, clusterStatus *k8sclient.ClusterStatus) error {
+	container, resources, tolerance, err := resizer.GetExpectedResources(clusterStatus)
+	if err != nil {
+		glog.Errorf("Error calculating expected resources: %v", err)
+		return err
+	}
+	glog.V(4).Infof("Expected resources of container %s: requests %v, limits %v", container, resources.Requests, resources.Limits)
+
+	// Update resource target with expected resources.
+	err = k8sClient.UpdateContainerResources(container, resources, tolerance)
 	if err != nil {
 		glog.Errorf("Update failure: %s", err)
 	}
@@ -19,10 +19,14 @@ package autoscaler
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	testingclock "k8s.io/utils/clock/testing"
 
@@ -272,3 +276,217 @@ type mockHealthServer struct {
 
 func (s mockHealthServer) Start() {
 }
+
+func TestPollAPIServer_HPA(t *testing.T) {
+	testConfigMap := v1.ConfigMap{
+		Data: map[string]string{
+			linearcontroller.ControllerType: `{"nodesPerReplica": 10, "min": 1}`,
+		},
+	}
+	testConfigMap.ObjectMeta.ResourceVersion = `1`
+
+	testCases := []struct {
+		hpaName                  string
+		hpaMaxReplicasMultiplier float64
+		targetHPAs               []string
+		expError                 bool
+		expReplicas              int
+		expHPAMinReplicas        int32
+		expHPAMaxReplicas        int32
+	}{
+		// Direct mode scales the target
+		{"", 0, nil, false, 5, 0, 0},
+		// Direct mode refuses to fight a HorizontalPodAutoscaler
+		{"", 0, []string{"web"}, true, 0, 0, 0},
+		// HorizontalPodAutoscaler mode leaves the target to it
+		{"web", 0, []string{"web"}, false, 0, 5, 0},
+		{"web", 2.5, []string{"web"}, false, 0, 5, 13},
+	}
+
+	for _, tc := range testCases {
+		mockK8s := k8sclient.MockK8sClient{
+			NumOfNodes: 50,
+			ConfigMap:  &testConfigMap,
+			TargetHPAs: tc.targetHPAs,
+		}
+		autoScaler := &AutoScaler{
+			k8sClient:                &mockK8s,
+			hpaName:                  tc.hpaName,
+			hpaMaxReplicasMultiplier: tc.hpaMaxReplicasMultiplier,
+		}
+		err := autoScaler.pollAPIServer()
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for HPAs %v", tc.targetHPAs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if mockK8s.NumOfReplicas != tc.expReplicas || mockK8s.HPAMinReplicas != tc.expHPAMinReplicas || mockK8s.HPAMaxReplicas != tc.expHPAMaxReplicas {
+			t.Errorf("Expected replicas %d, HPA replicas %d-%d, got replicas %d, HPA replicas %d-%d",
+				tc.expReplicas, tc.expHPAMinReplicas, tc.expHPAMaxReplicas,
+				mockK8s.NumOfReplicas, mockK8s.HPAMinReplicas, mockK8s.HPAMaxReplicas)
+		}
+	}
+}
+
+func TestPollAPIServer_PDB(t *testing.T) {
+	testConfigMap := v1.ConfigMap{
+		Data: map[string]string{
+			linearcontroller.ControllerType: `{"nodesPerReplica": 1, "min": 1}`,
+		},
+	}
+	testConfigMap.ObjectMeta.ResourceVersion = `1`
+
+	testCases := []struct {
+		nodes                  int
+		pdbMinAvailableRatio   float64
+		pdbMaxUnavailableRatio float64
+		expMinAvailable        *intstr.IntOrString
+		expMaxUnavailable      *intstr.IntOrString
+	}{
+		{40, 0, 0, nil, nil},
+		{40, 0, 0.1, nil, intStrPtr(4)},
+		{2, 0, 0.1, nil, intStrPtr(1)},
+		{30, 0, 0.1, nil, intStrPtr(3)},
+		{40, 0.9, 0, intStrPtr(36), nil},
+		// A disruption is still allowed
+		{2, 0.9, 0, intStrPtr(1), nil},
+		{100, 0.29, 0, intStrPtr(29), nil},
+	}
+
+	for _, tc := range testCases {
+		mockK8s := k8sclient.MockK8sClient{
+			NumOfNodes: tc.nodes,
+			ConfigMap:  &testConfigMap,
+		}
+		autoScaler := &AutoScaler{
+			k8sClient:              &mockK8s,
+			pdbMinAvailableRatio:   tc.pdbMinAvailableRatio,
+			pdbMaxUnavailableRatio: tc.pdbMaxUnavailableRatio,
+		}
+		if err := autoScaler.pollAPIServer(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(mockK8s.PDBMinAvailable, tc.expMinAvailable) || !reflect.DeepEqual(mockK8s.PDBMaxUnavailable, tc.expMaxUnavailable) {
+			t.Errorf("With %d replicas expected minAvailable %v maxUnavailable %v, got %v and %v",
+				tc.nodes, tc.expMinAvailable, tc.expMaxUnavailable, mockK8s.PDBMinAvailable, mockK8s.PDBMaxUnavailable)
+		}
+	}
+}
+
+func intStrPtr(value int32) *intstr.IntOrString {
+	v := intstr.FromInt32(value)
+	return &v
+}
+
+func TestPollAPIServer_Publish(t *testing.T) {
+	paramsConfigMap := &v1.ConfigMap{
+		Data: map[string]string{
+			linearcontroller.ControllerType: `{"nodesPerReplica": 10, "min": 1}`,
+		},
+	}
+	paramsConfigMap.ObjectMeta.ResourceVersion = `1`
+	notFound := apierrors.NewNotFound(v1.Resource("configmaps"), "shards")
+
+	testCases := []struct {
+		name       string
+		nodes      int
+		published  map[string]string
+		expCreated map[string]string
+		expUpdated map[string]string
+		expDropped []string
+	}{
+		{
+			name:       "created if missing",
+			nodes:      50,
+			expCreated: map[string]string{"value": "5", "input.nodes": "50", "input.cores": "0"},
+		},
+		{
+			name:       "updated when the value changes",
+			nodes:      50,
+			published:  map[string]string{"value": "4", "input.nodes": "40", "input.cores": "0"},
+			expUpdated: map[string]string{"value": "5", "input.nodes": "50", "input.cores": "0"},
+		},
+		{
+			name:      "left as is when only the inputs change",
+			nodes:     49,
+			published: map[string]string{"value": "5", "input.nodes": "41", "input.cores": "0"},
+		},
+		{
+			name:       "updated keeping the other keys but not stale inputs",
+			nodes:      50,
+			published:  map[string]string{"value": "4", "shards.yaml": "owner: app", "input.queue": "7"},
+			expUpdated: map[string]string{"value": "5", "shards.yaml": "owner: app", "input.nodes": "50"},
+			expDropped: []string{"input.queue"},
+		},
+	}
+
+	for _, tc := range testCases {
+		var created, updated map[string]string
+		mockK8s := k8sclient.MockK8sClient{
+			NumOfNodes: tc.nodes,
+			FetchConfigMapFn: func(namespace, configmap string) (*v1.ConfigMap, error) {
+				if configmap != "shards" {
+					return paramsConfigMap, nil
+				}
+				if tc.published == nil {
+					return nil, notFound
+				}
+				return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configmap, Labels: map[string]string{"app": "shards"}}, Data: tc.published}, nil
+			},
+			CreateConfigMapFn: func(namespace, configmap string, params map[string]string) (*v1.ConfigMap, error) {
+				created = params
+				return &v1.ConfigMap{Data: params}, nil
+			},
+			UpdateConfigMapDataFn: func(configMap *v1.ConfigMap, data map[string]string) (*v1.ConfigMap, error) {
+				if configMap.Labels["app"] != "shards" {
+					t.Errorf("%s: expected the fetched ConfigMap to be updated, got %v", tc.name, configMap)
+				}
+				updated = data
+				return &v1.ConfigMap{Data: data}, nil
+			},
+		}
+		autoScaler := &AutoScaler{
+			k8sClient:       &mockK8s,
+			clusterSignals:  &clusterSignalProvider{},
+			configMapName:   "params",
+			outputConfigMap: "shards",
+		}
+		if err := autoScaler.RegisterSignalProvider(ClusterSignalProvider, autoScaler.clusterSignals); err != nil {
+			t.Fatal(err)
+		}
+		if err := autoScaler.pollAPIServer(); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if mockK8s.NumOfReplicas != 0 {
+			t.Errorf("%s: expected the target to be left as is, got %d replicas", tc.name, mockK8s.NumOfReplicas)
+		}
+		if !publishedAs(created, tc.expCreated) || !publishedAs(updated, tc.expUpdated) {
+			t.Errorf("%s: expected created %v updated %v, got created %v updated %v", tc.name, tc.expCreated, tc.expUpdated, created, updated)
+		}
+		for _, key := range tc.expDropped {
+			if _, ok := updated[key]; ok {
+				t.Errorf("%s: expected %s to be dropped, got %v", tc.name, key, updated)
+			}
+		}
+	}
+}
+
+// publishedAs returns whether the published data holds the expected keys.
+func publishedAs(data, expected map[string]string) bool {
+	if (data == nil) != (expected == nil) {
+		return false
+	}
+	for key, value := range expected {
+		if data[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitycontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&CapacityController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "capacity"
+)
+
+// PodRequestsGetter reads the requests of a pod of the target
+type PodRequestsGetter interface {
+	// GetTargetPodRequests returns the cpu and memory requests of a pod of the
+	// target, as read from its pod template
+	GetTargetPodRequests() (v1.ResourceList, error)
+}
+
+// CapacityController scales the target to reserve a fraction of the cluster
+// capacity, given the requests of its pods
+type CapacityController struct {
+	podRequestsGetter PodRequestsGetter
+	params            *capacityParams
+	version           string
+}
+
+// NewCapacityController returns a new capacity controller reading the requests
+// of the pods of the target with podRequestsGetter
+func NewCapacityController(podRequestsGetter PodRequestsGetter) controller.Controller {
+	return &CapacityController{podRequestsGetter: podRequestsGetter}
+}
+
+type capacityParams struct {
+	// CoresFraction and MemoryFraction are the fractions of the cluster cores
+	// and memory the pods of the target should request.
+	CoresFraction             float64 `json:"coresFraction"`
+	MemoryFraction            float64 `json:"memoryFraction"`
+	IncludeUnschedulableNodes bool    `json:"includeUnschedulableNodes"`
+}
+
+func (c *CapacityController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing capacity params: %s", err)
+	}
+	c.params = params
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string
+func parseParams(data []byte) (*capacityParams, error) {
+	var p capacityParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if p.CoresFraction == 0 && p.MemoryFraction == 0 {
+		return nil, fmt.Errorf("should at least provide either CoresFraction or MemoryFraction (Greater than 0)")
+	}
+	if p.CoresFraction < 0 || p.CoresFraction > 1 {
+		return nil, fmt.Errorf("coresFraction should be between 0 and 1, got: %v", p.CoresFraction)
+	}
+	if p.MemoryFraction < 0 || p.MemoryFraction > 1 {
+		return nil, fmt.Errorf("memoryFraction should be between 0 and 1, got: %v", p.MemoryFraction)
+	}
+	return &p, nil
+}
+
+func (c *CapacityController) GetParamsVersion() string {
+	return c.version
+}
+
+func (c *CapacityController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	// The pod template is read on each poll, so that a change of the requests
+	// of the target is followed right away.
+	requests, err := c.podRequestsGetter.GetTargetPodRequests()
+	if err != nil {
+		return 0, fmt.Errorf("error getting pod requests of the target: %v", err)
+	}
+	cores := float64(status.SchedulableCores)
+	memory := float64(status.SchedulableMemory)
+	if c.params.IncludeUnschedulableNodes {
+		cores = float64(status.TotalCores)
+		memory = float64(status.TotalMemory)
+	}
+
+	cpuRequest := requests[v1.ResourceCPU]
+	memoryRequest := requests[v1.ResourceMemory]
+	replicasFromCores, err := c.getExpectedReplicasFromFraction(cores, c.params.CoresFraction, float64(cpuRequest.MilliValue())/1000)
+	if err != nil {
+		return 0, fmt.Errorf("cannot reserve a fraction of the cores: %v", err)
+	}
+	replicasFromMemory, err := c.getExpectedReplicasFromFraction(memory, c.params.MemoryFraction, float64(memoryRequest.Value()))
+	if err != nil {
+		return 0, fmt.Errorf("cannot reserve a fraction of the memory: %v", err)
+	}
+	// Returns the results which yields the most replicas
+	if replicasFromCores > replicasFromMemory {
+		return int32(replicasFromCores), nil
+	}
+	return int32(replicasFromMemory), nil
+}
+
+func (c *CapacityController) getExpectedReplicasFromFraction(capacity, fraction, podRequest float64) (int, error) {
+	if fraction == 0 {
+		return 1, nil
+	}
+	if podRequest <= 0 {
+		return 0, fmt.Errorf("the pods of the target do not request it")
+	}
+	return int(math.Ceil(controller.RoundOff(fraction * capacity / podRequest))), nil
+}
+
+func (c *CapacityController) GetControllerType() string {
+	return ControllerType
+}
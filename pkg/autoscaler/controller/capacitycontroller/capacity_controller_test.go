@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitycontroller
+
+import (
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *capacityParams
+	}{
+		{
+			`{
+			  "coresFraction": 0.02,
+			  "memoryFraction": 0.01,
+			  "includeUnschedulableNodes": true
+			}`,
+			false,
+			&capacityParams{
+				CoresFraction:             0.02,
+				MemoryFraction:            0.01,
+				IncludeUnschedulableNodes: true,
+			},
+		},
+		{ // Invalid JSON
+			`{ "coresFraction": {{ 1:1 } }`,
+			true,
+			nil,
+		},
+		{ // Both fractions are unset
+			`{ "min": 1, "max": 100 }`,
+			true,
+			nil,
+		},
+		{ // Fractions cannot exceed the whole cluster
+			`{ "coresFraction": 1.5 }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative fraction
+			`{ "coresFraction": 0.02, "memoryFraction": -0.01 }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if *params != *tc.expParams {
+			t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", tc.expParams, params)
+		}
+	}
+}
+
+func TestScaleFromCapacity(t *testing.T) {
+	status := &k8sclient.ClusterStatus{
+		TotalCores:        1200,
+		SchedulableCores:  1000,
+		TotalMemory:       1200 << 30,
+		SchedulableMemory: 1000 << 30,
+	}
+	podRequests := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("500m"),
+		v1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+
+	testCases := []struct {
+		params      capacityParams
+		requests    v1.ResourceList
+		expError    bool
+		expReplicas int32
+	}{
+		// 2% of 1000 cores is 20 cores, that is 40 pods of 500m
+		{capacityParams{CoresFraction: 0.02}, podRequests, false, 40},
+		{capacityParams{CoresFraction: 0.02, IncludeUnschedulableNodes: true}, podRequests, false, 48},
+		// 5% of 1000Gi is 50 pods of 1Gi
+		{capacityParams{CoresFraction: 0.02, MemoryFraction: 0.05}, podRequests, false, 50},
+		{capacityParams{CoresFraction: 0.0001}, podRequests, false, 1},
+		// The pods of the target do not request memory
+		{capacityParams{MemoryFraction: 0.05}, v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}, true, 0},
+		// The target cannot be read
+		{capacityParams{CoresFraction: 0.02}, nil, true, 0},
+	}
+
+	for _, tc := range testCases {
+		params := tc.params
+		c := &CapacityController{
+			podRequestsGetter: &k8sclient.MockK8sClient{TargetPodRequests: tc.requests},
+			params:            &params,
+		}
+		replicas, err := c.GetExpectedReplicas(status)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for params %+v", tc.params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetExpectedReplicas() failed Expected %d, Got %d", tc.expReplicas, replicas)
+			spew.Dump(tc)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compositecontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&CompositeController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "composite"
+
+	// ReducerMax yields the replicas of the child asking for the most
+	ReducerMax = "max"
+	// ReducerMin yields the replicas of the child asking for the fewest
+	ReducerMin = "min"
+	// ReducerSum yields the sum of the replicas of all children
+	ReducerSum = "sum"
+	// ReducerWeightedAverage yields the average of the replicas of all
+	// children, weighted by their weight
+	ReducerWeightedAverage = "weightedAverage"
+)
+
+// NewControllerFunc creates the controller of a control mode, it is used to
+// create the children of a composite controller.
+type NewControllerFunc func(mode string) (controller.Controller, error)
+
+// CompositeController combines the replicas of several child controllers
+type CompositeController struct {
+	newController NewControllerFunc
+	params        *compositeParams
+	children      []child
+	version       string
+}
+
+// NewCompositeController returns a new composite controller creating its
+// children with newController
+func NewCompositeController(newController NewControllerFunc) controller.Controller {
+	return &CompositeController{newController: newController}
+}
+
+type compositeParams struct {
+	Reducer     string        `json:"reducer"`
+	Controllers []childParams `json:"controllers"`
+}
+
+type childParams struct {
+	// Name identifies the child in the logs, it defaults to the mode.
+	Name string `json:"name"`
+	Mode string `json:"mode"`
+	// Weight is only used by the weightedAverage reducer, it defaults to 1.
+	Weight float64 `json:"weight"`
+	// Params are the parameters of the child, as they would appear in the
+	// ConfigMap under the key of its mode.
+	Params json.RawMessage `json:"params"`
+}
+
+type child struct {
+	name       string
+	weight     float64
+	controller controller.Controller
+}
+
+func (c *CompositeController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing composite params: %s", err)
+	}
+	children := make([]child, 0, len(params.Controllers))
+	for _, p := range params.Controllers {
+		cont, err := c.newController(p.Mode)
+		if err != nil {
+			return fmt.Errorf("error creating controller %q: %v", p.Name, err)
+		}
+		childConfigMap := &v1.ConfigMap{
+			ObjectMeta: configMap.ObjectMeta,
+			Data:       map[string]string{p.Mode: string(p.Params)},
+		}
+		if err := cont.SyncConfig(childConfigMap); err != nil {
+			return fmt.Errorf("error syncing controller %q: %v", p.Name, err)
+		}
+		children = append(children, child{name: p.Name, weight: p.Weight, controller: cont})
+	}
+	c.params = params
+	c.children = children
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string
+func parseParams(data []byte) (*compositeParams, error) {
+	var p compositeParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	switch p.Reducer {
+	case "":
+		p.Reducer = ReducerMax
+	case ReducerMax, ReducerMin, ReducerSum, ReducerWeightedAverage:
+	default:
+		return nil, fmt.Errorf("not a supported reducer: %q, expected one of %s, %s, %s or %s", p.Reducer, ReducerMax, ReducerMin, ReducerSum, ReducerWeightedAverage)
+	}
+	if len(p.Controllers) == 0 {
+		return nil, fmt.Errorf("should at least provide one controller")
+	}
+	names := make(map[string]bool, len(p.Controllers))
+	for i := range p.Controllers {
+		child := &p.Controllers[i]
+		if child.Mode == "" {
+			return nil, fmt.Errorf("mode of controller %d should be provided", i)
+		}
+		if child.Name == "" {
+			child.Name = child.Mode
+		}
+		if names[child.Name] {
+			return nil, fmt.Errorf("duplicated controller name %q, controllers of the same mode should be named", child.Name)
+		}
+		names[child.Name] = true
+		if child.Weight < 0 {
+			return nil, fmt.Errorf("invalid negative weight for controller %q: %v", child.Name, child.Weight)
+		} else if child.Weight == 0 {
+			child.Weight = 1
+		}
+		if len(child.Params) == 0 {
+			child.Params = json.RawMessage("{}")
+		}
+	}
+	return &p, nil
+}
+
+func (c *CompositeController) GetParamsVersion() string {
+	return c.version
+}
+
+func (c *CompositeController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	replicas := make([]int32, len(c.children))
+	for i, ch := range c.children {
+		r, err := ch.controller.GetExpectedReplicas(status)
+		if err != nil {
+			return 0, fmt.Errorf("error getting replicas of controller %q: %v", ch.name, err)
+		}
+		replicas[i] = r
+	}
+	res, explanation := c.reduce(replicas)
+	glog.V(2).Infof("Composite controller replicas: %s", explanation)
+	return int32(res), nil
+}
+
+// reduce combines the replicas of the children and explains how each child
+// contributed to the result.
+func (c *CompositeController) reduce(replicas []int32) (float64, string) {
+	contributions := make([]string, len(c.children))
+	for i, ch := range c.children {
+		contributions[i] = fmt.Sprintf("%s=%d", ch.name, replicas[i])
+	}
+	explanation := fmt.Sprintf("%s(%s)", c.params.Reducer, strings.Join(contributions, ", "))
+
+	switch c.params.Reducer {
+	case ReducerMin, ReducerMax:
+		// The first child wins ties
+		winner := 0
+		for i := range replicas {
+			if c.params.Reducer == ReducerMax && replicas[i] > replicas[winner] ||
+				c.params.Reducer == ReducerMin && replicas[i] < replicas[winner] {
+				winner = i
+			}
+		}
+		return float64(replicas[winner]), fmt.Sprintf("%s = %d won by %s", explanation, replicas[winner], c.children[winner].name)
+	case ReducerSum:
+		var sum float64
+		for _, r := range replicas {
+			sum += float64(r)
+		}
+		return sum, fmt.Sprintf("%s = %v", explanation, sum)
+	default:
+		var sum, weights float64
+		for i, ch := range c.children {
+			sum += ch.weight * float64(replicas[i])
+			weights += ch.weight
+		}
+		// Fractional replicas are rounded up
+		avg := math.Ceil(sum / weights)
+		return avg, fmt.Sprintf("%s = %v", explanation, avg)
+	}
+}
+
+func (c *CompositeController) GetControllerType() string {
+	return ControllerType
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compositecontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+// fixedController always expects the replicas found in its params, or fails
+// when they are negative.
+type fixedController struct {
+	replicas int32
+}
+
+func (c *fixedController) SyncConfig(configMap *v1.ConfigMap) error {
+	return json.Unmarshal([]byte(configMap.Data["fixed"]), &c.replicas)
+}
+
+func (c *fixedController) GetExpectedReplicas(*k8sclient.ClusterStatus) (int32, error) {
+	if c.replicas < 0 {
+		return 0, fmt.Errorf("failing controller")
+	}
+	return c.replicas, nil
+}
+
+func (c *fixedController) GetParamsVersion() string {
+	return ""
+}
+
+func (c *fixedController) GetControllerType() string {
+	return "fixed"
+}
+
+func newFixedController(mode string) (controller.Controller, error) {
+	if mode != "fixed" {
+		return nil, fmt.Errorf("not a supported control mode: %v", mode)
+	}
+	return &fixedController{}, nil
+}
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *compositeParams
+	}{
+		{
+			`{
+			  "reducer": "weightedAverage",
+			  "controllers": [
+			    { "name": "nodes", "mode": "ladder", "weight": 3, "params": { "nodesToReplicas": [ [ 1, 1 ] ] } },
+			    { "mode": "linear", "params": { "coresPerReplica": 16 } }
+			  ]
+			}`,
+			false,
+			&compositeParams{
+				Reducer: ReducerWeightedAverage,
+				Controllers: []childParams{
+					{Name: "nodes", Mode: "ladder", Weight: 3, Params: json.RawMessage(`{ "nodesToReplicas": [ [ 1, 1 ] ] }`)},
+					{Name: "linear", Mode: "linear", Weight: 1, Params: json.RawMessage(`{ "coresPerReplica": 16 }`)},
+				},
+			},
+		},
+		{ // Reducer defaults to max
+			`{ "controllers": [ { "mode": "linear" } ] }`,
+			false,
+			&compositeParams{
+				Reducer:     ReducerMax,
+				Controllers: []childParams{{Name: "linear", Mode: "linear", Weight: 1, Params: json.RawMessage(`{}`)}},
+			},
+		},
+		{ // Invalid JSON
+			`{ "controllers": {{ 1:1 } }`,
+			true,
+			nil,
+		},
+		{ // Unsupported reducer
+			`{ "reducer": "median", "controllers": [ { "mode": "linear" } ] }`,
+			true,
+			nil,
+		},
+		{ // No controllers
+			`{ "reducer": "max" }`,
+			true,
+			nil,
+		},
+		{ // Missing mode
+			`{ "controllers": [ { "name": "nodes" } ] }`,
+			true,
+			nil,
+		},
+		{ // Controllers of the same mode should be named
+			`{ "controllers": [ { "mode": "linear" }, { "mode": "linear" } ] }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative weight
+			`{ "controllers": [ { "mode": "linear", "weight": -1 } ] }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if fmt.Sprintf("%+v", params) != fmt.Sprintf("%+v", tc.expParams) {
+			t.Errorf("Parser error - Expected params %+v MISMATCHED: Got %+v", tc.expParams, params)
+		}
+	}
+}
+
+func TestGetExpectedReplicas(t *testing.T) {
+	testCases := []struct {
+		jsonData    string
+		expError    bool
+		expReplicas int32
+	}{
+		{
+			`{ "controllers": [ { "name": "a", "mode": "fixed", "params": 3 }, { "name": "b", "mode": "fixed", "params": 7 } ] }`,
+			false,
+			7,
+		},
+		{
+			`{ "reducer": "min", "controllers": [ { "name": "a", "mode": "fixed", "params": 3 }, { "name": "b", "mode": "fixed", "params": 7 } ] }`,
+			false,
+			3,
+		},
+		{
+			`{ "reducer": "sum", "controllers": [ { "name": "a", "mode": "fixed", "params": 3 }, { "name": "b", "mode": "fixed", "params": 7 } ] }`,
+			false,
+			10,
+		},
+		{ // (3*3 + 7) / 4 = 4
+			`{ "reducer": "weightedAverage", "controllers": [ { "name": "a", "mode": "fixed", "weight": 3, "params": 3 }, { "name": "b", "mode": "fixed", "params": 7 } ] }`,
+			false,
+			4,
+		},
+		{ // (3 + 8) / 2 = 5.5, rounded up to 6
+			`{ "reducer": "weightedAverage", "controllers": [ { "name": "a", "mode": "fixed", "params": 3 }, { "name": "b", "mode": "fixed", "params": 8 } ] }`,
+			false,
+			6,
+		},
+		{ // A failing child fails the composite
+			`{ "controllers": [ { "name": "a", "mode": "fixed", "params": 3 }, { "name": "b", "mode": "fixed", "params": -1 } ] }`,
+			true,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		c := NewCompositeController(newFixedController)
+		if err := c.SyncConfig(&v1.ConfigMap{Data: map[string]string{ControllerType: tc.jsonData}}); err != nil {
+			t.Errorf("Unexpected sync failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		replicas, err := c.GetExpectedReplicas(&k8sclient.ClusterStatus{})
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for params %s", tc.jsonData)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetExpectedReplicas() failed Expected %d, Got %d", tc.expReplicas, replicas)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func TestSyncConfigErrors(t *testing.T) {
+	testCases := []string{
+		// Unsupported child mode
+		`{ "controllers": [ { "mode": "linear" } ] }`,
+		// Invalid child params
+		`{ "controllers": [ { "mode": "fixed", "params": "three" } ] }`,
+	}
+
+	for _, data := range testCases {
+		c := NewCompositeController(newFixedController)
+		if err := c.SyncConfig(&v1.ConfigMap{Data: map[string]string{ControllerType: data}}); err == nil {
+			t.Errorf("Expect error, got no error for params %s", data)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package controller
 
 import (
+	"math"
+
 	"k8s.io/api/core/v1"
 
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
@@ -33,3 +35,18 @@ type Controller interface {
 	// GetControllerType returns the controller type
 	GetControllerType() string
 }
+
+// Resizer is implemented by controllers which resize a container of the target
+// instead of scaling its replicas
+type Resizer interface {
+	// GetExpectedResources returns the container to resize, its expected
+	// resources based on cluster status, and the relative difference from them
+	// within which its current resources are left as is
+	GetExpectedResources(*k8sclient.ClusterStatus) (container string, resources v1.ResourceRequirements, tolerance float64, err error)
+}
+
+// RoundOff rounds off floating point errors, so that e.g. 30 * 0.1 yields 3
+// rather than 4 once rounded up.
+func RoundOff(x float64) float64 {
+	return math.Round(x*1e6) / 1e6
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expressioncontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&ExpressionController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "expression"
+
+	// expressionCostLimit bounds the evaluation cost of an expression, so that
+	// a runaway comprehension cannot stall the autoscaler.
+	expressionCostLimit = 1000000
+)
+
+// ExpressionController computes the replicas with a CEL expression over the
+// cluster status
+type ExpressionController struct {
+	params  *expressionParams
+	version string
+}
+
+// NewExpressionController returns a new expression controller
+func NewExpressionController() controller.Controller {
+	return &ExpressionController{}
+}
+
+type expressionParams struct {
+	Expression string `json:"expression"`
+
+	// program is the compiled expression.
+	program cel.Program
+}
+
+// newEnv declares the variables an expression may refer to. Counts and
+// amounts are doubles so that they mix freely in arithmetic, and the maps are
+// keyed by extended resource or signal name.
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("nodes", cel.DoubleType),
+		cel.Variable("schedulableNodes", cel.DoubleType),
+		cel.Variable("cores", cel.DoubleType),
+		cel.Variable("schedulableCores", cel.DoubleType),
+		cel.Variable("memory", cel.DoubleType),
+		cel.Variable("schedulableMemory", cel.DoubleType),
+		cel.Variable("pods", cel.DoubleType),
+		cel.Variable("requestedCores", cel.DoubleType),
+		cel.Variable("requestedMemory", cel.DoubleType),
+		cel.Variable("services", cel.DoubleType),
+		cel.Variable("endpoints", cel.DoubleType),
+		cel.Variable("extendedResources", cel.MapType(cel.StringType, cel.DoubleType)),
+		cel.Variable("schedulableExtendedResources", cel.MapType(cel.StringType, cel.DoubleType)),
+		cel.Variable("signals", cel.MapType(cel.StringType, cel.DoubleType)),
+		ext.Math(),
+	)
+}
+
+func (c *ExpressionController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing expression params: %s", err)
+	}
+	c.params = params
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string and compile the expression
+func parseParams(data []byte) (*expressionParams, error) {
+	var p expressionParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if p.Expression == "" {
+		return nil, fmt.Errorf("expression should be provided")
+	}
+	program, err := compile(p.Expression)
+	if err != nil {
+		return nil, err
+	}
+	p.program = program
+	return &p, nil
+}
+
+// compile type-checks the expression, which should evaluate to a number.
+func compile(expression string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", expression, issues.Err())
+	}
+	outputType := ast.OutputType()
+	if !outputType.IsExactType(cel.IntType) &&
+		!outputType.IsExactType(cel.UintType) &&
+		!outputType.IsExactType(cel.DoubleType) &&
+		!outputType.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression %q should evaluate to a number, got: %v", expression, outputType)
+	}
+	return env.Program(ast, cel.CostLimit(expressionCostLimit))
+}
+
+func (c *ExpressionController) GetParamsVersion() string {
+	return c.version
+}
+
+func (c *ExpressionController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	value, err := c.evaluate(status)
+	if err != nil {
+		return 0, err
+	}
+	// Fractional replicas are rounded up, and negative ones raised to 0
+	res := math.Min(math.Ceil(value), math.MaxInt32)
+	return int32(math.Max(0, res)), nil
+}
+
+// evaluate runs the expression against the cluster status.
+func (c *ExpressionController) evaluate(status *k8sclient.ClusterStatus) (float64, error) {
+	signals := status.Signals
+	if signals == nil {
+		signals = map[string]float64{}
+	}
+	out, _, err := c.params.program.Eval(map[string]interface{}{
+		"nodes":                        float64(status.TotalNodes),
+		"schedulableNodes":             float64(status.SchedulableNodes),
+		"cores":                        float64(status.TotalCores),
+		"schedulableCores":             float64(status.SchedulableCores),
+		"memory":                       float64(status.TotalMemory),
+		"schedulableMemory":            float64(status.SchedulableMemory),
+		"pods":                         float64(status.TotalPods),
+		"requestedCores":               status.RequestedCores,
+		"requestedMemory":              float64(status.RequestedMemory),
+		"services":                     float64(status.TotalServices),
+		"endpoints":                    float64(status.TotalEndpoints),
+		"extendedResources":            toFloats(status.TotalExtendedResources),
+		"schedulableExtendedResources": toFloats(status.SchedulableExtendedResources),
+		"signals":                      signals,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error evaluating expression %q: %v", c.params.Expression, err)
+	}
+	var value float64
+	switch v := out.Value().(type) {
+	case int64:
+		value = float64(v)
+	case uint64:
+		value = float64(v)
+	case float64:
+		value = v
+	default:
+		return 0, fmt.Errorf("expression %q evaluated to %v (%T), expected a number", c.params.Expression, out.Value(), out.Value())
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("expression %q evaluated to %v", c.params.Expression, value)
+	}
+	return value, nil
+}
+
+func toFloats(amounts map[string]int64) map[string]float64 {
+	floats := make(map[string]float64, len(amounts))
+	for name, amount := range amounts {
+		floats[name] = float64(amount)
+	}
+	return floats
+}
+
+func (c *ExpressionController) GetControllerType() string {
+	return ControllerType
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expressioncontroller
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *expressionParams
+	}{
+		{
+			`{ "expression": "nodes / 4.0 + cores / 64.0", "min": 2, "max": 100 }`,
+			false,
+			&expressionParams{Expression: "nodes / 4.0 + cores / 64.0"},
+		},
+		{
+			`{ "expression": "math.greatest(signals.routes / 50.0, nodes / 16.0)" }`,
+			false,
+			&expressionParams{Expression: "math.greatest(signals.routes / 50.0, nodes / 16.0)"},
+		},
+		{ // Integer results are accepted
+			`{ "expression": "3" }`,
+			false,
+			&expressionParams{Expression: "3"},
+		},
+		{ // Invalid JSON
+			`{ "expression": {{ 1:1 } }`,
+			true,
+			nil,
+		},
+		{ // Missing expression
+			`{ "min": 1, "max": 100 }`,
+			true,
+			nil,
+		},
+		{ // Syntax error
+			`{ "expression": "nodes / " }`,
+			true,
+			nil,
+		},
+		{ // Undeclared variable
+			`{ "expression": "racks * 2.0" }`,
+			true,
+			nil,
+		},
+		{ // Mixed numeric types without conversion
+			`{ "expression": "nodes / 4" }`,
+			true,
+			nil,
+		},
+		{ // Not a number
+			`{ "expression": "nodes > 4.0" }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if params.Expression != tc.expParams.Expression {
+			t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", tc.expParams, params)
+		}
+		if params.program == nil {
+			t.Errorf("Expected the expression %q to be compiled", params.Expression)
+		}
+	}
+}
+
+func TestGetExpectedReplicas(t *testing.T) {
+	status := &k8sclient.ClusterStatus{
+		TotalNodes:       20,
+		SchedulableNodes: 16,
+		TotalCores:       320,
+		SchedulableCores: 256,
+		TotalExtendedResources: map[string]int64{
+			"nvidia.com/gpu": 8,
+		},
+		SchedulableExtendedResources: map[string]int64{
+			"nvidia.com/gpu": 4,
+		},
+		Signals: map[string]float64{
+			"routes": 120,
+		},
+	}
+
+	testCases := []struct {
+		expression  string
+		expError    bool
+		expReplicas int32
+	}{
+		{"schedulableNodes / 4.0", false, 4},
+		{"nodes / 3.0", false, 7},
+		{"math.greatest(cores / 64.0, signals.routes / 50.0)", false, 5},
+		{`extendedResources["nvidia.com/gpu"] + schedulableExtendedResources["nvidia.com/gpu"]`, false, 12},
+		{`"queue" in signals ? signals.queue / 10.0 : nodes / 10.0`, false, 2},
+		{"int(nodes) * 2", false, 40},
+		{"nodes - 100.0", false, 0},
+		{"nodes * 1e12", false, 2147483647},
+		// Missing signals fail the evaluation
+		{"signals.queue / 10.0", true, 0},
+		{"nodes / 0.0", true, 0},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(`{ "expression": ` + strconv.Quote(tc.expression) + ` }`))
+		if err != nil {
+			t.Errorf("Unexpected parse failure for %q: %v", tc.expression, err)
+			continue
+		}
+		c := &ExpressionController{params: params}
+		replicas, err := c.GetExpectedReplicas(status)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for expression %q", tc.expression)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for expression %q: %v", tc.expression, err)
+			spew.Dump(tc)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetExpectedReplicas() for expression %q failed Expected %d, Got %d", tc.expression, tc.expReplicas, replicas)
+		}
+	}
+}
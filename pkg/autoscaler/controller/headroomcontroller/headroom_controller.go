@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headroomcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&HeadroomController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "headroom"
+)
+
+// TargetRequestsGetter reads the requests of the pods of the targets
+type TargetRequestsGetter interface {
+	// GetTargetRequests returns the summed cpu and memory requests of the
+	// scheduled pods of the targets, as counted in the cluster status
+	GetTargetRequests() (v1.ResourceList, error)
+}
+
+// HeadroomController scales placeholder workloads so that a percentage of the
+// allocatable capacity stays unrequested
+type HeadroomController struct {
+	targetRequestsGetter TargetRequestsGetter
+	params               *headroomParams
+	version              string
+}
+
+// NewHeadroomController returns a new headroom controller leaving out the
+// requests of the pods of the targets read with targetRequestsGetter
+func NewHeadroomController(targetRequestsGetter TargetRequestsGetter) controller.Controller {
+	return &HeadroomController{targetRequestsGetter: targetRequestsGetter}
+}
+
+type headroomParams struct {
+	// HeadroomPercent is the percentage of the allocatable capacity to keep
+	// unrequested by other workloads.
+	HeadroomPercent float64 `json:"headroomPercent"`
+	// CPUPerReplica and MemoryPerReplica are the requests of a replica.
+	CPUPerReplica    string `json:"cpuPerReplica"`
+	MemoryPerReplica string `json:"memoryPerReplica"`
+
+	// coresPerReplica and memoryBytesPerReplica are the parsed requests.
+	coresPerReplica       float64
+	memoryBytesPerReplica float64
+}
+
+func (c *HeadroomController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing headroom params: %s", err)
+	}
+	c.params = params
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string
+func parseParams(data []byte) (*headroomParams, error) {
+	var p headroomParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if p.HeadroomPercent <= 0 || p.HeadroomPercent >= 100 {
+		return nil, fmt.Errorf("headroomPercent should be greater than 0 and less than 100, got: %v", p.HeadroomPercent)
+	}
+	if p.CPUPerReplica != "" {
+		q, err := resource.ParseQuantity(p.CPUPerReplica)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity for cpuPerReplica %q: %v", p.CPUPerReplica, err)
+		}
+		if q.Sign() <= 0 {
+			return nil, fmt.Errorf("cpuPerReplica should be greater than 0, got: %v", p.CPUPerReplica)
+		}
+		p.coresPerReplica = float64(q.MilliValue()) / 1000
+	}
+	if p.MemoryPerReplica != "" {
+		q, err := resource.ParseQuantity(p.MemoryPerReplica)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity for memoryPerReplica %q: %v", p.MemoryPerReplica, err)
+		}
+		if q.Sign() <= 0 {
+			return nil, fmt.Errorf("memoryPerReplica should be greater than 0, got: %v", p.MemoryPerReplica)
+		}
+		p.memoryBytesPerReplica = float64(q.Value())
+	}
+	if p.coresPerReplica == 0 && p.memoryBytesPerReplica == 0 {
+		return nil, fmt.Errorf("should at least provide either cpuPerReplica or memoryPerReplica")
+	}
+	return &p, nil
+}
+
+func (c *HeadroomController) GetParamsVersion() string {
+	return c.version
+}
+
+func (c *HeadroomController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	if !status.RequestsCounted {
+		return 0, fmt.Errorf("headroom mode requires --count-requests")
+	}
+	targetRequests, err := c.targetRequestsGetter.GetTargetRequests()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the requests of the pods of the targets: %v", err)
+	}
+	// The pods of the targets hold the headroom, they are left out of the
+	// requests of the other workloads.
+	targetCores := targetRequests[v1.ResourceCPU]
+	targetMemory := targetRequests[v1.ResourceMemory]
+	requestedCores := math.Max(0, status.RequestedCores-float64(targetCores.MilliValue())/1000)
+	requestedMemory := math.Max(0, float64(status.RequestedMemory-targetMemory.Value()))
+
+	cores := float64(status.SchedulableCores)
+	memory := float64(status.SchedulableMemory)
+	if cores > 0 && memory > 0 {
+		glog.V(4).Infof("Cores unrequested by other workloads: %.1f%%, memory: %.1f%%, target headroom: %v%%",
+			100*(cores-requestedCores)/cores, 100*(memory-requestedMemory)/memory, c.params.HeadroomPercent)
+	}
+
+	replicasFromCores := c.getExpectedReplicasFromRequests(requestedCores, c.params.coresPerReplica)
+	replicasFromMemory := c.getExpectedReplicasFromRequests(requestedMemory, c.params.memoryBytesPerReplica)
+	// Returns the results which yields the most replicas
+	if replicasFromCores > replicasFromMemory {
+		return int32(replicasFromCores), nil
+	}
+	return int32(replicasFromMemory), nil
+}
+
+// getExpectedReplicasFromRequests returns the replicas holding the headroom of
+// a resource. The replicas are not part of the requests, and the cluster grows
+// until their requests fit, so once settled
+//
+//	allocatable = requested + replicas * perReplica
+//
+// and keeping (allocatable - requested) / allocatable at the headroom ratio h
+// yields replicas = requested * h / (1 - h) / perReplica. Unlike a target
+// computed from the current allocatable, this does not feed back into itself
+// as the cluster grows.
+func (c *HeadroomController) getExpectedReplicasFromRequests(requested, perReplica float64) int {
+	if perReplica == 0 {
+		return 0
+	}
+	h := c.params.HeadroomPercent / 100
+	return int(math.Ceil(controller.RoundOff(requested * h / (1 - h) / perReplica)))
+}
+
+func (c *HeadroomController) GetControllerType() string {
+	return ControllerType
+}
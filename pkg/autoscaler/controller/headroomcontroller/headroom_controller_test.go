@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headroomcontroller
+
+import (
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *headroomParams
+	}{
+		{
+			`{
+			  "headroomPercent": 20,
+			  "cpuPerReplica": "500m",
+			  "memoryPerReplica": "1Gi"
+			}`,
+			false,
+			&headroomParams{
+				HeadroomPercent:       20,
+				CPUPerReplica:         "500m",
+				MemoryPerReplica:      "1Gi",
+				coresPerReplica:       0.5,
+				memoryBytesPerReplica: 1 << 30,
+			},
+		},
+		{
+			`{ "headroomPercent": 10, "cpuPerReplica": "2" }`,
+			false,
+			&headroomParams{HeadroomPercent: 10, CPUPerReplica: "2", coresPerReplica: 2},
+		},
+		{ // Invalid JSON
+			`{ "headroomPercent": {{ 1:1 } }`,
+			true,
+			nil,
+		},
+		{ // Missing headroom
+			`{ "cpuPerReplica": "1" }`,
+			true,
+			nil,
+		},
+		{ // Headroom cannot be the whole cluster
+			`{ "headroomPercent": 100, "cpuPerReplica": "1" }`,
+			true,
+			nil,
+		},
+		{ // Missing requests per replica
+			`{ "headroomPercent": 10 }`,
+			true,
+			nil,
+		},
+		{ // Invalid quantity
+			`{ "headroomPercent": 10, "memoryPerReplica": "1 gigabyte" }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative quantity
+			`{ "headroomPercent": 10, "cpuPerReplica": "-1" }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if *params != *tc.expParams {
+			t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", tc.expParams, params)
+		}
+	}
+}
+
+func TestScaleFromRequests(t *testing.T) {
+	testCases := []struct {
+		params         string
+		status         k8sclient.ClusterStatus
+		targetRequests v1.ResourceList
+		expReplicas    int32
+	}{
+		{ // 20 cores of headroom over 80 requested cores are 20% of 100 cores
+			`{ "headroomPercent": 20, "cpuPerReplica": "1" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true, SchedulableCores: 100, RequestedCores: 80},
+			nil,
+			20,
+		},
+		{
+			`{ "headroomPercent": 20, "cpuPerReplica": "2" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true, SchedulableCores: 100, RequestedCores: 80},
+			nil,
+			10,
+		},
+		{
+			`{ "headroomPercent": 20, "cpuPerReplica": "3" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true, SchedulableCores: 100, RequestedCores: 80},
+			nil,
+			7,
+		},
+		{ // The placeholder pods are left out of the requests
+			`{ "headroomPercent": 20, "cpuPerReplica": "1" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true, SchedulableCores: 100, RequestedCores: 100},
+			v1.ResourceList{v1.ResourceCPU: resource.MustParse("20")},
+			20,
+		},
+		{ // Memory yields the most replicas
+			`{ "headroomPercent": 50, "cpuPerReplica": "1", "memoryPerReplica": "1Gi" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true, RequestedCores: 4, RequestedMemory: 20 << 30},
+			v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+			16,
+		},
+		{
+			`{ "headroomPercent": 10, "cpuPerReplica": "1" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true},
+			nil,
+			0,
+		},
+		{
+			`{ "headroomPercent": 50, "cpuPerReplica": "1" }`,
+			k8sclient.ClusterStatus{RequestsCounted: true, RequestedCores: 400},
+			nil,
+			400,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.params))
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			continue
+		}
+		c := &HeadroomController{
+			targetRequestsGetter: &k8sclient.MockK8sClient{CountRequests: true, TargetRequests: tc.targetRequests},
+			params:               params,
+		}
+		replicas, err := c.GetExpectedReplicas(&tc.status)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetExpectedReplicas() for %s failed Expected %d, Got %d", tc.params, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestRequestsNotCounted(t *testing.T) {
+	params, err := parseParams([]byte(`{ "headroomPercent": 20, "cpuPerReplica": "1" }`))
+	if err != nil {
+		t.Fatalf("Unexpected parse failure: %v", err)
+	}
+	c := &HeadroomController{targetRequestsGetter: &k8sclient.MockK8sClient{}, params: params}
+	if _, err := c.GetExpectedReplicas(&k8sclient.ClusterStatus{SchedulableCores: 100}); err == nil {
+		t.Errorf("Expected an error without the pod requests counted")
+	}
+}
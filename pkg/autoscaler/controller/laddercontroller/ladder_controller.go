@@ -19,9 +19,12 @@ package laddercontroller
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
@@ -34,8 +37,27 @@ var _ = controller.Controller(&LadderController{})
 const (
 	// ControllerType defines the controller type string
 	ControllerType = "ladder"
+
+	// RoundingCeil rounds interpolated replicas up
+	RoundingCeil = "ceil"
+	// RoundingFloor rounds interpolated replicas down
+	RoundingFloor = "floor"
+	// RoundingNearest rounds interpolated replicas to the nearest integer
+	RoundingNearest = "nearest"
+
+	toReplicasSuffix = "ToReplicas"
 )
 
+// builtinToReplicasParams are the "*ToReplicas" params which are not extended resources nor signals.
+var builtinToReplicasParams = []string{
+	"coresToReplicas",
+	"nodesToReplicas",
+	"memoryToReplicas",
+	"podsToReplicas",
+	"servicesToReplicas",
+	"endpointsToReplicas",
+}
+
 // LadderController uses ladder control pattern
 type LadderController struct {
 	params  *ladderParams
@@ -47,8 +69,23 @@ func NewLadderController() controller.Controller {
 	return &LadderController{}
 }
 
+// ladderEntry is a single step of a ladder, mapping a resource threshold
+// to a replicas count.
+type ladderEntry interface {
+	threshold() int64
+	replicas() int
+}
+
 type paramEntry [2]int
 
+func (e paramEntry) threshold() int64 {
+	return int64(e[0])
+}
+
+func (e paramEntry) replicas() int {
+	return e[1]
+}
+
 type paramEntries []paramEntry
 
 func (entries paramEntries) Len() int {
@@ -63,10 +100,68 @@ func (entries paramEntries) Swap(i, j int) {
 	entries[i], entries[j] = entries[j], entries[i]
 }
 
+// memoryEntry maps an amount of memory in bytes to a replicas count. It is
+// written as a [quantity, replicas] pair, e.g. [ "64Gi", 3 ].
+type memoryEntry struct {
+	bytes int64
+	count int
+}
+
+func (e memoryEntry) threshold() int64 {
+	return e.bytes
+}
+
+func (e memoryEntry) replicas() int {
+	return e.count
+}
+
+func (e *memoryEntry) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return fmt.Errorf("invalid element %s in memory_to_replicas_map", data)
+	}
+	var q resource.Quantity
+	if err := json.Unmarshal(raw[0], &q); err != nil {
+		return fmt.Errorf("invalid quantity in entry %s in memory_to_replicas_map: %v", data, err)
+	}
+	if err := json.Unmarshal(raw[1], &e.count); err != nil {
+		return fmt.Errorf("invalid replicas in entry %s in memory_to_replicas_map: %v", data, err)
+	}
+	e.bytes = q.Value()
+	return nil
+}
+
+type memoryEntries []memoryEntry
+
+func (entries memoryEntries) Len() int {
+	return len(entries)
+}
+
+func (entries memoryEntries) Less(i, j int) bool {
+	return entries[i].bytes < entries[j].bytes
+}
+
+func (entries memoryEntries) Swap(i, j int) {
+	entries[i], entries[j] = entries[j], entries[i]
+}
+
 type ladderParams struct {
-	CoresToReplicas           paramEntries `json:"coresToReplicas"`
-	NodesToReplicas           paramEntries `json:"nodesToReplicas"`
-	IncludeUnschedulableNodes bool         `json:"includeUnschedulableNodes"`
+	CoresToReplicas           paramEntries  `json:"coresToReplicas"`
+	NodesToReplicas           paramEntries  `json:"nodesToReplicas"`
+	MemoryToReplicas          memoryEntries `json:"memoryToReplicas"`
+	PodsToReplicas            paramEntries  `json:"podsToReplicas"`
+	ServicesToReplicas        paramEntries  `json:"servicesToReplicas"`
+	EndpointsToReplicas       paramEntries  `json:"endpointsToReplicas"`
+	IncludeUnschedulableNodes bool          `json:"includeUnschedulableNodes"`
+	Interpolate               bool          `json:"interpolate"`
+	Rounding                  string        `json:"rounding"`
+
+	// resourcesToReplicas holds the "<resource>ToReplicas" params of extended
+	// resources and signals, keyed by resource or signal name.
+	resourcesToReplicas map[string]paramEntries
 }
 
 func (c *LadderController) SyncConfig(configMap *v1.ConfigMap) error {
@@ -78,6 +173,13 @@ func (c *LadderController) SyncConfig(configMap *v1.ConfigMap) error {
 	}
 	sort.Sort(params.CoresToReplicas)
 	sort.Sort(params.NodesToReplicas)
+	sort.Sort(params.MemoryToReplicas)
+	sort.Sort(params.PodsToReplicas)
+	sort.Sort(params.ServicesToReplicas)
+	sort.Sort(params.EndpointsToReplicas)
+	for _, entries := range params.resourcesToReplicas {
+		sort.Sort(entries)
+	}
 	c.params = params
 	c.version = configMap.ObjectMeta.ResourceVersion
 	return nil
@@ -105,29 +207,133 @@ func parseParams(data []byte) (*ladderParams, error) {
 			return nil, fmt.Errorf("invalid negative values in entry %v in nodes_to_replicas_map", e)
 		}
 	}
+	for _, e := range p.PodsToReplicas {
+		if e[0] < 0 || e[1] < 0 {
+			return nil, fmt.Errorf("invalid negative values in entry %v in pods_to_replicas_map", e)
+		}
+	}
+	for _, e := range p.ServicesToReplicas {
+		if e[0] < 0 || e[1] < 0 {
+			return nil, fmt.Errorf("invalid negative values in entry %v in services_to_replicas_map", e)
+		}
+	}
+	for _, e := range p.EndpointsToReplicas {
+		if e[0] < 0 || e[1] < 0 {
+			return nil, fmt.Errorf("invalid negative values in entry %v in endpoints_to_replicas_map", e)
+		}
+	}
+	for _, e := range p.MemoryToReplicas {
+		if e.bytes < 0 || e.count < 0 {
+			return nil, fmt.Errorf("invalid negative values in entry %v in memory_to_replicas_map", e)
+		}
+	}
+	resourcesToReplicas, err := parseResourcesToReplicas(data)
+	if err != nil {
+		return nil, err
+	}
+	p.resourcesToReplicas = resourcesToReplicas
+	switch p.Rounding {
+	case "":
+		p.Rounding = RoundingCeil
+	case RoundingCeil, RoundingFloor, RoundingNearest:
+	default:
+		return nil, fmt.Errorf("invalid rounding policy %q, expected one of %q, %q or %q", p.Rounding, RoundingCeil, RoundingFloor, RoundingNearest)
+	}
 	return &p, nil
 }
 
+// parseResourcesToReplicas collects the "<resource>ToReplicas" params of
+// extended resources and signals, e.g. "nvidia.com/gpuToReplicas".
+func parseResourcesToReplicas(data []byte) (map[string]paramEntries, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	resourcesToReplicas := make(map[string]paramEntries)
+	for key, value := range raw {
+		name := strings.TrimSuffix(key, toReplicasSuffix)
+		if name == key || name == "" || isBuiltinToReplicasParam(key) {
+			continue
+		}
+		var entries paramEntries
+		if err := json.Unmarshal(value, &entries); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		for _, e := range entries {
+			if e[0] < 0 || e[1] < 0 {
+				return nil, fmt.Errorf("invalid negative values in entry %v in %s", e, key)
+			}
+		}
+		resourcesToReplicas[name] = entries
+	}
+	return resourcesToReplicas, nil
+}
+
+func isBuiltinToReplicasParam(key string) bool {
+	for _, param := range builtinToReplicasParams {
+		// encoding/json matches struct fields case-insensitively.
+		if strings.EqualFold(key, param) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *LadderController) GetParamsVersion() string {
 	return c.version
 }
 
 func (c *LadderController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	if len(c.params.PodsToReplicas) > 0 && !status.PodsCounted {
+		return 0, fmt.Errorf("podsToReplicas requires --count-pods")
+	}
+	if (len(c.params.ServicesToReplicas) > 0 || len(c.params.EndpointsToReplicas) > 0) && !status.ServicesCounted {
+		return 0, fmt.Errorf("servicesToReplicas and endpointsToReplicas require --count-services")
+	}
+
 	var expReplicas int32
+	memory := status.SchedulableMemory
 	if c.params.IncludeUnschedulableNodes {
 		// Get the expected replicas for the total nodes and cores
 		expReplicas = int32(c.getExpectedReplicasFromParams(int(status.TotalNodes), int(status.TotalCores)))
+		memory = status.TotalMemory
 	} else {
 		// Get the expected replicas for the currently schedulable nodes and cores
 		expReplicas = int32(c.getExpectedReplicasFromParams(int(status.SchedulableNodes), int(status.SchedulableCores)))
 	}
 
+	replicasFromInputs := []int{
+		getReplicas(memory, c.params.MemoryToReplicas, c.params.Interpolate, c.params.Rounding),
+		getReplicas(int64(status.TotalPods), c.params.PodsToReplicas, c.params.Interpolate, c.params.Rounding),
+		getReplicas(int64(status.TotalServices), c.params.ServicesToReplicas, c.params.Interpolate, c.params.Rounding),
+		getReplicas(int64(status.TotalEndpoints), c.params.EndpointsToReplicas, c.params.Interpolate, c.params.Rounding),
+	}
+	for name, entries := range c.params.resourcesToReplicas {
+		amount, ok := status.LookupInput(name, c.params.IncludeUnschedulableNodes)
+		if !ok {
+			return 0, fmt.Errorf("%q is neither an extended resource listed in --extended-resources nor a signal", name)
+		}
+		replicasFromInputs = append(replicasFromInputs, getReplicas(int64(amount), entries, c.params.Interpolate, c.params.Rounding))
+	}
+
+	for _, replicas := range replicasFromInputs {
+		if int32(replicas) > expReplicas {
+			expReplicas = int32(replicas)
+		}
+	}
+
 	return expReplicas, nil
 }
 
 func (c *LadderController) getExpectedReplicasFromParams(nodes, cores int) int {
-	replicasFromCore := getExpectedReplicasFromEntries(cores, c.params.CoresToReplicas)
-	replicasFromNode := getExpectedReplicasFromEntries(nodes, c.params.NodesToReplicas)
+	var replicasFromCore, replicasFromNode int
+	if c.params.Interpolate {
+		replicasFromCore = getInterpolatedReplicasFromEntries(cores, c.params.CoresToReplicas, c.params.Rounding)
+		replicasFromNode = getInterpolatedReplicasFromEntries(nodes, c.params.NodesToReplicas, c.params.Rounding)
+	} else {
+		replicasFromCore = getExpectedReplicasFromEntries(cores, c.params.CoresToReplicas)
+		replicasFromNode = getExpectedReplicasFromEntries(nodes, c.params.NodesToReplicas)
+	}
 
 	// Returns the results which yields the most replicas
 	if replicasFromCore > replicasFromNode {
@@ -137,6 +343,26 @@ func (c *LadderController) getExpectedReplicasFromParams(nodes, cores int) int {
 }
 
 func getExpectedReplicasFromEntries(resources int, entries []paramEntry) int {
+	return lookupReplicas(int64(resources), entries)
+}
+
+// getInterpolatedReplicasFromEntries linearly interpolates the replicas between
+// the two entries surrounding resources. Values outside of the ladder are
+// clamped to the first and last entries.
+func getInterpolatedReplicasFromEntries(resources int, entries []paramEntry, rounding string) int {
+	return interpolateReplicas(int64(resources), entries, rounding)
+}
+
+// getReplicas looks up the replicas for resources, interpolating between
+// entries if requested.
+func getReplicas[E ladderEntry](resources int64, entries []E, interpolate bool, rounding string) int {
+	if interpolate {
+		return interpolateReplicas(resources, entries, rounding)
+	}
+	return lookupReplicas(resources, entries)
+}
+
+func lookupReplicas[E ladderEntry](resources int64, entries []E) int {
 	if len(entries) == 0 {
 		return 0
 	}
@@ -144,12 +370,41 @@ func getExpectedReplicasFromEntries(resources int, entries []paramEntry) int {
 	pos := sort.Search(
 		len(entries),
 		func(i int) bool {
-			return resources < entries[i][0]
+			return resources < entries[i].threshold()
 		})
 	if pos > 0 {
 		pos = pos - 1
 	}
-	return entries[pos][1]
+	return entries[pos].replicas()
+}
+
+func interpolateReplicas[E ladderEntry](resources int64, entries []E, rounding string) int {
+	if len(entries) == 0 {
+		return 0
+	}
+	pos := sort.Search(
+		len(entries),
+		func(i int) bool {
+			return resources < entries[i].threshold()
+		})
+	if pos == 0 {
+		return entries[0].replicas()
+	}
+	if pos == len(entries) {
+		return entries[pos-1].replicas()
+	}
+	lower, upper := entries[pos-1], entries[pos]
+	// Multiply before dividing so that exact steps are not skewed by rounding errors.
+	replicas := float64(lower.replicas()) +
+		float64(resources-lower.threshold())*float64(upper.replicas()-lower.replicas())/float64(upper.threshold()-lower.threshold())
+	switch rounding {
+	case RoundingFloor:
+		return int(math.Floor(replicas))
+	case RoundingNearest:
+		return int(math.Round(replicas))
+	default:
+		return int(math.Ceil(replicas))
+	}
 }
 
 func (c *LadderController) GetControllerType() string {
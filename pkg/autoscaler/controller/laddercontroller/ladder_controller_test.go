@@ -399,3 +399,483 @@ func TestScaleFromUnschedulableNodes(t *testing.T) {
 		}
 	}
 }
+
+func TestControllerParserRounding(t *testing.T) {
+	testCases := []struct {
+		jsonData    string
+		expError    bool
+		expRounding string
+	}{
+		{
+			`{ "coresToReplicas" : [ [1,1] ], "interpolate": true }`,
+			false,
+			RoundingCeil,
+		},
+		{
+			`{ "coresToReplicas" : [ [1,1] ], "interpolate": true, "rounding": "floor" }`,
+			false,
+			RoundingFloor,
+		},
+		{
+			`{ "coresToReplicas" : [ [1,1] ], "interpolate": true, "rounding": "nearest" }`,
+			false,
+			RoundingNearest,
+		},
+		{ // Unknown rounding policy
+			`{ "coresToReplicas" : [ [1,1] ], "interpolate": true, "rounding": "up" }`,
+			true,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if !params.Interpolate || params.Rounding != tc.expRounding {
+			t.Errorf("Parser error - Expected interpolation with rounding %q, Got interpolate=%v rounding %q", tc.expRounding, params.Interpolate, params.Rounding)
+		}
+	}
+}
+
+func TestControllerInterpolatedScaler(t *testing.T) {
+	testEntries := []paramEntry{
+		{1, 1},
+		{64, 3},
+		{512, 5},
+		{1024, 5},
+		{2048, 10},
+	}
+
+	testCases := []struct {
+		numResources int
+		expCeil      int
+		expFloor     int
+		expNearest   int
+	}{
+		{0, 1, 1, 1},
+		{1, 1, 1, 1},
+		{32, 2, 1, 2},
+		{64, 3, 3, 3},
+		{65, 4, 3, 3},
+		{288, 4, 4, 4},
+		{400, 5, 4, 5},
+		{511, 5, 4, 5},
+		{512, 5, 5, 5},
+		{800, 5, 5, 5},
+		{1024, 5, 5, 5},
+		{1100, 6, 5, 5},
+		{1536, 8, 7, 8},
+		{2048, 10, 10, 10},
+		{4096, 10, 10, 10},
+	}
+
+	for _, tc := range testCases {
+		if replicas := getInterpolatedReplicasFromEntries(tc.numResources, testEntries, RoundingCeil); tc.expCeil != replicas {
+			t.Errorf("Interpolated ceil lookup failed for %d: Expected %d, Got %d", tc.numResources, tc.expCeil, replicas)
+		}
+		if replicas := getInterpolatedReplicasFromEntries(tc.numResources, testEntries, RoundingFloor); tc.expFloor != replicas {
+			t.Errorf("Interpolated floor lookup failed for %d: Expected %d, Got %d", tc.numResources, tc.expFloor, replicas)
+		}
+		if replicas := getInterpolatedReplicasFromEntries(tc.numResources, testEntries, RoundingNearest); tc.expNearest != replicas {
+			t.Errorf("Interpolated nearest lookup failed for %d: Expected %d, Got %d", tc.numResources, tc.expNearest, replicas)
+		}
+	}
+
+	if replicas := getInterpolatedReplicasFromEntries(10, []paramEntry{}, RoundingCeil); replicas != 0 {
+		t.Errorf("Interpolated lookup on empty entries failed Expected 0, Got %d", replicas)
+	}
+}
+
+func TestInterpolatedGetExpectedReplicas(t *testing.T) {
+	c := &LadderController{
+		params: &ladderParams{
+			CoresToReplicas: []paramEntry{
+				{0, 1},
+				{100, 11},
+			},
+			NodesToReplicas: []paramEntry{
+				{0, 1},
+				{10, 3},
+			},
+			Interpolate: true,
+			Rounding:    RoundingCeil,
+		},
+	}
+
+	testCases := []struct {
+		clusterStatus    *k8sclient.ClusterStatus
+		expectedReplicas int32
+	}{
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 5, SchedulableCores: 10},
+			expectedReplicas: 2,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 5, SchedulableCores: 55},
+			expectedReplicas: 7,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 50, SchedulableCores: 500},
+			expectedReplicas: 11,
+		},
+	}
+
+	for _, tc := range testCases {
+		actualReplicas, err := c.GetExpectedReplicas(tc.clusterStatus)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if tc.expectedReplicas != actualReplicas {
+			t.Errorf("Interpolated GetExpectedReplicas failed Expected %d, Got %d", tc.expectedReplicas, actualReplicas)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func TestControllerParserMemory(t *testing.T) {
+	testCases := []struct {
+		jsonData   string
+		expError   bool
+		expEntries memoryEntries
+	}{
+		{
+			`{ "memoryToReplicas" : [ [ "64Gi", 2 ], [ 1024, 1 ], [ "1Ti", 10 ] ] }`,
+			false,
+			memoryEntries{{64 << 30, 2}, {1024, 1}, {1 << 40, 10}},
+		},
+		{ // Invalid quantity
+			`{ "memoryToReplicas" : [ [ "lots", 2 ] ] }`,
+			true,
+			nil,
+		},
+		{ // Invalid entry length
+			`{ "memoryToReplicas" : [ [ "1Gi", 2, 3 ] ] }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative in list
+			`{ "memoryToReplicas" : [ [ "-1Gi", 2 ] ] }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if len(params.MemoryToReplicas) != len(tc.expEntries) {
+			t.Errorf("Scaler Params length mismatch Expected: %d, Got %d", len(tc.expEntries), len(params.MemoryToReplicas))
+			continue
+		}
+		for n, expected := range tc.expEntries {
+			if parsed := params.MemoryToReplicas[n]; parsed != expected {
+				t.Errorf("Scaler parser error - Expected value %v MISMATCHED: Got %v", expected, parsed)
+			}
+		}
+	}
+}
+
+func TestScaleFromMemory(t *testing.T) {
+	memoryToReplicas := memoryEntries{
+		{0, 1},
+		{64 << 30, 2},
+		{128 << 30, 4},
+	}
+
+	testcases := []struct {
+		clusterStatus    *k8sclient.ClusterStatus
+		interpolate      bool
+		expectedReplicas int32
+	}{
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableMemory: 1 << 30},
+			expectedReplicas: 1,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableMemory: 64 << 30},
+			expectedReplicas: 2,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableMemory: 96 << 30},
+			expectedReplicas: 2,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableMemory: 96 << 30},
+			interpolate:      true,
+			expectedReplicas: 3,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableMemory: 1 << 50},
+			expectedReplicas: 4,
+		},
+		{
+			// Nodes still take precedence when they yield more replicas.
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 3, SchedulableMemory: 96 << 30},
+			expectedReplicas: 3,
+		},
+	}
+
+	for _, tc := range testcases {
+		c := &LadderController{
+			params: &ladderParams{
+				NodesToReplicas:  []paramEntry{{0, 0}, {3, 3}},
+				MemoryToReplicas: memoryToReplicas,
+				Interpolate:      tc.interpolate,
+				Rounding:         RoundingCeil,
+			},
+		}
+		actualReplicas, err := c.GetExpectedReplicas(tc.clusterStatus)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if tc.expectedReplicas != actualReplicas {
+			t.Errorf("ScaleFromMemory failed Expected %d, Got %d", tc.expectedReplicas, actualReplicas)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func TestControllerParserExtendedResources(t *testing.T) {
+	testCases := []struct {
+		jsonData   string
+		expError   bool
+		expEntries map[string]paramEntries
+	}{
+		{
+			`{ "coresToReplicas" : [ [1,1] ], "nvidia.com/gpuToReplicas": [ [0, 0], [8, 2] ] }`,
+			false,
+			map[string]paramEntries{"nvidia.com/gpu": {{0, 0}, {8, 2}}},
+		},
+		{ // Built-in params are not extended resources
+			`{ "CoresToReplicas" : [ [1,1] ], "nodesToReplicas": [ [1, 1] ] }`,
+			false,
+			map[string]paramEntries{},
+		},
+		{ // Invalid entries
+			`{ "nvidia.com/gpuToReplicas": [ [ "a", 1 ] ] }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative in list
+			`{ "nvidia.com/gpuToReplicas": [ [ 1, -1 ] ] }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if len(params.resourcesToReplicas) != len(tc.expEntries) {
+			t.Errorf("Scaler Params length mismatch Expected: %v, Got %v", tc.expEntries, params.resourcesToReplicas)
+			continue
+		}
+		for name, expected := range tc.expEntries {
+			verifyParams(t, &ladderParams{CoresToReplicas: params.resourcesToReplicas[name]}, &ladderParams{CoresToReplicas: expected})
+		}
+	}
+}
+
+func TestScaleFromExtendedResources(t *testing.T) {
+	c := &LadderController{
+		params: &ladderParams{
+			NodesToReplicas: []paramEntry{{0, 1}},
+			resourcesToReplicas: map[string]paramEntries{
+				"nvidia.com/gpu": {{0, 0}, {1, 1}, {16, 2}, {64, 4}},
+				"routes":         {{0, 0}, {1000, 3}},
+			},
+			Rounding: RoundingCeil,
+		},
+	}
+
+	testcases := []struct {
+		clusterStatus    *k8sclient.ClusterStatus
+		expError         bool
+		expectedReplicas int32
+	}{
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 0}, Signals: map[string]float64{"routes": 0}},
+			expectedReplicas: 1,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 20}, Signals: map[string]float64{"routes": 0}},
+			expectedReplicas: 2,
+		},
+		{
+			clusterStatus: &k8sclient.ClusterStatus{
+				SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 100},
+				Signals:                      map[string]float64{"routes": 0},
+			},
+			expectedReplicas: 4,
+		},
+		{
+			clusterStatus: &k8sclient.ClusterStatus{
+				SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 20},
+				Signals:                      map[string]float64{"routes": 1000},
+			},
+			expectedReplicas: 3,
+		},
+		{
+			clusterStatus: &k8sclient.ClusterStatus{},
+			expError:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		actualReplicas, err := c.GetExpectedReplicas(tc.clusterStatus)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expected error, got replicas %d", actualReplicas)
+				spew.Dump(tc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if tc.expectedReplicas != actualReplicas {
+			t.Errorf("ScaleFromExtendedResources failed Expected %d, Got %d", tc.expectedReplicas, actualReplicas)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func TestScaleFromPods(t *testing.T) {
+	testcases := []struct {
+		clusterStatus    *k8sclient.ClusterStatus
+		interpolate      bool
+		expectedReplicas int32
+	}{
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, TotalPods: 10, PodsCounted: true},
+			expectedReplicas: 1,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, TotalPods: 1500, PodsCounted: true},
+			expectedReplicas: 2,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, TotalPods: 1500, PodsCounted: true},
+			interpolate:      true,
+			expectedReplicas: 3,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, TotalPods: 100000, PodsCounted: true},
+			expectedReplicas: 5,
+		},
+	}
+
+	for _, tc := range testcases {
+		c := &LadderController{
+			params: &ladderParams{
+				NodesToReplicas: []paramEntry{{0, 1}},
+				PodsToReplicas:  []paramEntry{{0, 1}, {1000, 2}, {2000, 3}, {10000, 5}},
+				Interpolate:     tc.interpolate,
+				Rounding:        RoundingCeil,
+			},
+		}
+		actualReplicas, err := c.GetExpectedReplicas(tc.clusterStatus)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if tc.expectedReplicas != actualReplicas {
+			t.Errorf("ScaleFromPods failed Expected %d, Got %d", tc.expectedReplicas, actualReplicas)
+			spew.Dump(tc)
+		}
+	}
+	// The pods are not counted without --count-pods.
+	c := &LadderController{params: &ladderParams{PodsToReplicas: []paramEntry{{0, 1}}}}
+	if _, err := c.GetExpectedReplicas(&k8sclient.ClusterStatus{SchedulableNodes: 1}); err == nil {
+		t.Errorf("Expect error, got no error for podsToReplicas without --count-pods")
+	}
+}
+
+func TestScaleFromServicesAndEndpoints(t *testing.T) {
+	testcases := []struct {
+		clusterStatus    *k8sclient.ClusterStatus
+		expectedReplicas int32
+	}{
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, ServicesCounted: true},
+			expectedReplicas: 1,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, TotalServices: 600, ServicesCounted: true},
+			expectedReplicas: 2,
+		},
+		{
+			clusterStatus:    &k8sclient.ClusterStatus{SchedulableNodes: 1, TotalServices: 600, TotalEndpoints: 20000, ServicesCounted: true},
+			expectedReplicas: 4,
+		},
+	}
+
+	for _, tc := range testcases {
+		c := &LadderController{
+			params: &ladderParams{
+				NodesToReplicas:     []paramEntry{{0, 1}},
+				ServicesToReplicas:  []paramEntry{{0, 1}, {500, 2}},
+				EndpointsToReplicas: []paramEntry{{0, 1}, {10000, 4}},
+				Rounding:            RoundingCeil,
+			},
+		}
+		actualReplicas, err := c.GetExpectedReplicas(tc.clusterStatus)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if tc.expectedReplicas != actualReplicas {
+			t.Errorf("ScaleFromServicesAndEndpoints failed Expected %d, Got %d", tc.expectedReplicas, actualReplicas)
+			spew.Dump(tc)
+		}
+	}
+	// The services and endpoints are not counted without --count-services.
+	c := &LadderController{params: &ladderParams{EndpointsToReplicas: []paramEntry{{0, 1}}}}
+	if _, err := c.GetExpectedReplicas(&k8sclient.ClusterStatus{SchedulableNodes: 1}); err == nil {
+		t.Errorf("Expect error, got no error for endpointsToReplicas without --count-services")
+	}
+}
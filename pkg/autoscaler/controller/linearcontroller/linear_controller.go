@@ -20,8 +20,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
@@ -34,8 +36,20 @@ var _ = controller.Controller(&LinearController{})
 const (
 	// ControllerType defines the controller type string
 	ControllerType = "linear"
+
+	perReplicaSuffix = "PerReplica"
 )
 
+// builtinPerReplicaParams are the "*PerReplica" params which are not extended resources nor signals.
+var builtinPerReplicaParams = []string{
+	"coresPerReplica",
+	"nodesPerReplica",
+	"memoryPerReplica",
+	"podsPerReplica",
+	"servicesPerReplica",
+	"endpointsPerReplica",
+}
+
 // LinearController uses linear control pattern
 type LinearController struct {
 	params  *linearParams
@@ -50,10 +64,17 @@ func NewLinearController() controller.Controller {
 type linearParams struct {
 	CoresPerReplica           float64 `json:"coresPerReplica"`
 	NodesPerReplica           float64 `json:"nodesPerReplica"`
-	Min                       int     `json:"min"`
-	Max                       int     `json:"max"`
-	PreventSinglePointFailure bool    `json:"preventSinglePointFailure"`
+	MemoryPerReplica          string  `json:"memoryPerReplica"`
+	PodsPerReplica            float64 `json:"podsPerReplica"`
+	ServicesPerReplica        float64 `json:"servicesPerReplica"`
+	EndpointsPerReplica       float64 `json:"endpointsPerReplica"`
 	IncludeUnschedulableNodes bool    `json:"includeUnschedulableNodes"`
+
+	// memoryBytesPerReplica is MemoryPerReplica parsed into bytes.
+	memoryBytesPerReplica float64
+	// resourcesPerReplica holds the "<resource>PerReplica" params of extended
+	// resources and signals, keyed by resource or signal name.
+	resourcesPerReplica map[string]float64
 }
 
 func (c *LinearController) SyncConfig(configMap *v1.ConfigMap) error {
@@ -74,17 +95,25 @@ func parseParams(data []byte) (*linearParams, error) {
 	if err := json.Unmarshal(data, &p); err != nil {
 		return nil, fmt.Errorf("could not parse parameters (%s)", err)
 	}
-	if p.Min < 0 {
-		return nil, fmt.Errorf("invalid negative value for min: %v", p.Min)
-	} else if p.Min == 0 {
-		glog.V(2).Infof("Defaulting min replicas count to 1 for linear controller")
-		p.Min = 1
+	if p.MemoryPerReplica != "" {
+		q, err := resource.ParseQuantity(p.MemoryPerReplica)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity for memoryPerReplica %q: %v", p.MemoryPerReplica, err)
+		}
+		if q.Sign() < 0 {
+			return nil, fmt.Errorf("invalid negative value for memoryPerReplica: %v", p.MemoryPerReplica)
+		}
+		p.memoryBytesPerReplica = float64(q.Value())
 	}
-	if p.Max != 0 && p.Max < p.Min {
-		return nil, fmt.Errorf("max replicas count %v should be greater than / equal to min replicas count %v", p.Max, p.Min)
+	resourcesPerReplica, err := parseResourcesPerReplica(data)
+	if err != nil {
+		return nil, err
 	}
-	if p.CoresPerReplica == 0 && p.NodesPerReplica == 0 {
-		return nil, fmt.Errorf("should at least provide either CoresPerReplica or NodesPerReplica (Greater than 0)")
+	p.resourcesPerReplica = resourcesPerReplica
+	if p.CoresPerReplica == 0 && p.NodesPerReplica == 0 && p.memoryBytesPerReplica == 0 &&
+		p.PodsPerReplica == 0 && p.ServicesPerReplica == 0 && p.EndpointsPerReplica == 0 &&
+		len(p.resourcesPerReplica) == 0 {
+		return nil, fmt.Errorf("should at least provide either CoresPerReplica, NodesPerReplica or another *PerReplica param (Greater than 0)")
 	}
 	if p.CoresPerReplica < 0 {
 		return nil, fmt.Errorf("invalid negative value for coresPerReplica: %v", p.CoresPerReplica)
@@ -92,9 +121,55 @@ func parseParams(data []byte) (*linearParams, error) {
 	if p.NodesPerReplica < 0 {
 		return nil, fmt.Errorf("invalid negative value for nodesPerReplica: %v", p.NodesPerReplica)
 	}
+	if p.PodsPerReplica < 0 {
+		return nil, fmt.Errorf("invalid negative value for podsPerReplica: %v", p.PodsPerReplica)
+	}
+	if p.ServicesPerReplica < 0 {
+		return nil, fmt.Errorf("invalid negative value for servicesPerReplica: %v", p.ServicesPerReplica)
+	}
+	if p.EndpointsPerReplica < 0 {
+		return nil, fmt.Errorf("invalid negative value for endpointsPerReplica: %v", p.EndpointsPerReplica)
+	}
 	return &p, nil
 }
 
+// parseResourcesPerReplica collects the "<resource>PerReplica" params of
+// extended resources and signals, e.g. "nvidia.com/gpuPerReplica".
+func parseResourcesPerReplica(data []byte) (map[string]float64, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	resourcesPerReplica := make(map[string]float64)
+	for key, value := range raw {
+		name := strings.TrimSuffix(key, perReplicaSuffix)
+		if name == key || name == "" || isBuiltinPerReplicaParam(key) {
+			continue
+		}
+		var perReplica float64
+		if err := json.Unmarshal(value, &perReplica); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		if perReplica < 0 {
+			return nil, fmt.Errorf("invalid negative value for %s: %v", key, perReplica)
+		}
+		if perReplica > 0 {
+			resourcesPerReplica[name] = perReplica
+		}
+	}
+	return resourcesPerReplica, nil
+}
+
+func isBuiltinPerReplicaParam(key string) bool {
+	for _, param := range builtinPerReplicaParams {
+		// encoding/json matches struct fields case-insensitively.
+		if strings.EqualFold(key, param) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *LinearController) GetParamsVersion() string {
 	return c.version
 }
@@ -103,6 +178,47 @@ func (c *LinearController) GetExpectedReplicas(status *k8sclient.ClusterStatus)
 	// Get the expected replicas for the currently number of nodes and cores
 	expReplicas := int32(c.getExpectedReplicasFromParams(int(status.SchedulableNodes), int(status.SchedulableCores), int(status.TotalNodes), int(status.TotalCores)))
 
+	if c.params.PodsPerReplica > 0 && !status.PodsCounted {
+		return 0, fmt.Errorf("podsPerReplica requires --count-pods")
+	}
+	if (c.params.ServicesPerReplica > 0 || c.params.EndpointsPerReplica > 0) && !status.ServicesCounted {
+		return 0, fmt.Errorf("servicesPerReplica and endpointsPerReplica require --count-services")
+	}
+
+	memory := status.SchedulableMemory
+	if c.params.IncludeUnschedulableNodes {
+		memory = status.TotalMemory
+	}
+	inputs := []struct {
+		value      float64
+		perReplica float64
+	}{
+		{float64(memory), c.params.memoryBytesPerReplica},
+		{float64(status.TotalPods), c.params.PodsPerReplica},
+		{float64(status.TotalServices), c.params.ServicesPerReplica},
+		{float64(status.TotalEndpoints), c.params.EndpointsPerReplica},
+	}
+	for name, perReplica := range c.params.resourcesPerReplica {
+		amount, ok := status.LookupInput(name, c.params.IncludeUnschedulableNodes)
+		if !ok {
+			return 0, fmt.Errorf("%q is neither an extended resource listed in --extended-resources nor a signal", name)
+		}
+		inputs = append(inputs, struct {
+			value      float64
+			perReplica float64
+		}{amount, perReplica})
+	}
+
+	// Other inputs are only taken into account when they yield more replicas
+	for _, input := range inputs {
+		if input.perReplica == 0 {
+			continue
+		}
+		if replicas := int32(c.getExpectedReplicasFromValue(input.value, input.perReplica)); replicas > expReplicas {
+			expReplicas = replicas
+		}
+	}
+
 	return expReplicas, nil
 }
 
@@ -115,13 +231,6 @@ func (c *LinearController) getExpectedReplicasFromParams(schedulableNodes, sched
 	}
 	replicasFromCore := c.getExpectedReplicasFromParam(cores, c.params.CoresPerReplica)
 	replicasFromNode := c.getExpectedReplicasFromParam(nodes, c.params.NodesPerReplica)
-	// Prevent single point of failure by having at least 2 replicas when
-	// there are more than one node.
-	if c.params.PreventSinglePointFailure &&
-		nodes > 1 &&
-		replicasFromNode < 2 {
-		replicasFromNode = 2
-	}
 
 	// Returns the results which yields the most replicas
 	if replicasFromCore > replicasFromNode {
@@ -131,14 +240,16 @@ func (c *LinearController) getExpectedReplicasFromParams(schedulableNodes, sched
 }
 
 func (c *LinearController) getExpectedReplicasFromParam(schedulableResources int, resourcesPerReplica float64) int {
-	if resourcesPerReplica == 0 {
+	return c.getExpectedReplicasFromValue(float64(schedulableResources), resourcesPerReplica)
+}
+
+// getExpectedReplicasFromValue is the float variant of getExpectedReplicasFromParam,
+// for inputs such as memory bytes which may not fit into an int.
+func (c *LinearController) getExpectedReplicasFromValue(value float64, valuePerReplica float64) int {
+	if valuePerReplica == 0 {
 		return 1
 	}
-	res := math.Ceil(float64(schedulableResources) / resourcesPerReplica)
-	if c.params.Max != 0 {
-		res = math.Min(float64(c.params.Max), res)
-	}
-	return int(math.Max(float64(c.params.Min), res))
+	return int(math.Ceil(value / valuePerReplica))
 }
 
 func (c *LinearController) GetControllerType() string {
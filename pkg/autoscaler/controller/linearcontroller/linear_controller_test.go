@@ -17,18 +17,43 @@ limitations under the License.
 package linearcontroller
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/modifier"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
 )
 
+// newTestController returns a linear controller synced with params, whose
+// replicas are bounded by the modifiers as in the autoscaler.
+func newTestController(t *testing.T, params string) controller.Controller {
+	c := modifier.NewController(NewLinearController(), 1)
+	if err := c.SyncConfig(&v1.ConfigMap{Data: map[string]string{ControllerType: params}}); err != nil {
+		t.Fatalf("Unexpected sync failure: %v", err)
+	}
+	return c
+}
+
 func verifyParams(t *testing.T, scalerParams, expScalerParams *linearParams) {
 	if scalerParams.CoresPerReplica != expScalerParams.CoresPerReplica ||
 		scalerParams.NodesPerReplica != expScalerParams.NodesPerReplica ||
-		scalerParams.Min != expScalerParams.Min ||
-		scalerParams.Max != expScalerParams.Max {
+		scalerParams.memoryBytesPerReplica != expScalerParams.memoryBytesPerReplica ||
+		scalerParams.PodsPerReplica != expScalerParams.PodsPerReplica {
 		t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", expScalerParams, scalerParams)
 	}
+	if len(scalerParams.resourcesPerReplica) != len(expScalerParams.resourcesPerReplica) {
+		t.Errorf("Parser error - Expected resources per replica %v MISMATCHED: Got %v", expScalerParams.resourcesPerReplica, scalerParams.resourcesPerReplica)
+		return
+	}
+	for name, expected := range expScalerParams.resourcesPerReplica {
+		if parsed, ok := scalerParams.resourcesPerReplica[name]; !ok || parsed != expected {
+			t.Errorf("Parser error - Expected %s per replica %v MISMATCHED: Got %v", name, expected, parsed)
+		}
+	}
 }
 
 func TestControllerParser(t *testing.T) {
@@ -50,9 +75,6 @@ func TestControllerParser(t *testing.T) {
 			&linearParams{
 				CoresPerReplica:           2,
 				NodesPerReplica:           1,
-				Min:                       1,
-				Max:                       100,
-				PreventSinglePointFailure: true,
 				IncludeUnschedulableNodes: true,
 			},
 		},
@@ -68,12 +90,77 @@ func TestControllerParser(t *testing.T) {
 			&linearParams{
 				CoresPerReplica:           2,
 				NodesPerReplica:           1,
-				Min:                       1,
-				Max:                       100,
-				PreventSinglePointFailure: true,
 				IncludeUnschedulableNodes: false,
 			},
 		},
+		{
+			`{
+		      "memoryPerReplica": "4Gi",
+		      "min": 1,
+		      "max": 100
+		    }`,
+			false,
+			&linearParams{
+				MemoryPerReplica:      "4Gi",
+				memoryBytesPerReplica: 4 << 30,
+			},
+		},
+		{
+			`{
+		      "nodesPerReplica": 16,
+		      "nvidia.com/gpuPerReplica": 8,
+		      "CoresPerReplica": 4
+		    }`,
+			false,
+			&linearParams{
+				CoresPerReplica: 4,
+				NodesPerReplica: 16,
+				resourcesPerReplica: map[string]float64{
+					"nvidia.com/gpu": 8,
+				},
+			},
+		},
+		{
+			`{ "nvidia.com/gpuPerReplica": 2.5 }`,
+			false,
+			&linearParams{
+				resourcesPerReplica: map[string]float64{
+					"nvidia.com/gpu": 2.5,
+				},
+			},
+		},
+		{
+			`{ "podsPerReplica": 500, "min": 2 }`,
+			false,
+			&linearParams{
+				PodsPerReplica: 500,
+			},
+		},
+		{ // Invalid negative pods value
+			`{ "podsPerReplica": -500 }`,
+			true,
+			&linearParams{},
+		},
+		{ // Invalid negative extended resource value
+			`{ "nvidia.com/gpuPerReplica": -1 }`,
+			true,
+			&linearParams{},
+		},
+		{ // Invalid extended resource value
+			`{ "nvidia.com/gpuPerReplica": "two" }`,
+			true,
+			&linearParams{},
+		},
+		{ // Invalid memory quantity
+			`{ "memoryPerReplica": "lots" }`,
+			true,
+			&linearParams{},
+		},
+		{ // Invalid negative memory quantity
+			`{ "memoryPerReplica": "-1Gi" }`,
+			true,
+			&linearParams{},
+		},
 		{ // Invalid JSON
 			`{ "coresPerReplica": {{ 1:1 } }`,
 			true,
@@ -89,15 +176,6 @@ func TestControllerParser(t *testing.T) {
 			true,
 			&linearParams{},
 		},
-		{ // Invalid max that smaller tham min
-			`{
-		      "nodesPerReplica": 1,
-		      "min": 100,
-		      "max": 50
-		    }`,
-			true,
-			&linearParams{},
-		},
 		{ // Both coresPerReplica and nodesPerReplica are unset
 			`{
 		      "min": 1,
@@ -152,12 +230,7 @@ func TestControllerParser(t *testing.T) {
 }
 
 func TestScaleFromSingleParam(t *testing.T) {
-	testController := &LinearController{}
-	testController.params = &linearParams{
-		CoresPerReplica: 2,
-		Min:             2,
-		Max:             100,
-	}
+	testController := newTestController(t, `{ "coresPerReplica": 2, "min": 2, "max": 100 }`)
 
 	testCases := []struct {
 		numResources int
@@ -180,22 +253,26 @@ func TestScaleFromSingleParam(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		if replicas := testController.getExpectedReplicasFromParam(tc.numResources, testController.params.CoresPerReplica); tc.expReplicas != replicas {
+		replicas, err := testController.GetExpectedReplicas(&k8sclient.ClusterStatus{SchedulableCores: int32(tc.numResources)})
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if int32(tc.expReplicas) != replicas {
 			t.Errorf("Scaler Lookup failed Expected %d, Got %d", tc.expReplicas, replicas)
 		}
 	}
 }
 
 func TestScaleFromMultipleParams(t *testing.T) {
-	testController := &LinearController{}
-	testController.params = &linearParams{
-		CoresPerReplica:           2,
-		NodesPerReplica:           2.5,
-		Min:                       1,
-		Max:                       100,
-		PreventSinglePointFailure: true,
-		IncludeUnschedulableNodes: false,
-	}
+	testController := newTestController(t, `{
+	  "coresPerReplica": 2,
+	  "nodesPerReplica": 2.5,
+	  "min": 1,
+	  "max": 100,
+	  "preventSinglePointFailure": true,
+	  "includeUnschedulableNodes": false
+	}`)
 
 	testCases := []struct {
 		numCores    int
@@ -220,22 +297,32 @@ func TestScaleFromMultipleParams(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		if replicas := testController.getExpectedReplicasFromParams(tc.numNodes, tc.numCores, tc.numNodes, tc.numNodes); tc.expReplicas != replicas {
+		status := &k8sclient.ClusterStatus{
+			SchedulableNodes: int32(tc.numNodes),
+			SchedulableCores: int32(tc.numCores),
+			TotalNodes:       int32(tc.numNodes),
+			TotalCores:       int32(tc.numNodes),
+		}
+		replicas, err := testController.GetExpectedReplicas(status)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if int32(tc.expReplicas) != replicas {
 			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
 		}
 	}
 }
 
 func TestScaleFromUnschedulableNodes(t *testing.T) {
-	testController := &LinearController{}
-	testController.params = &linearParams{
-		CoresPerReplica:           2,
-		NodesPerReplica:           2,
-		Min:                       1,
-		Max:                       100,
-		PreventSinglePointFailure: true,
-		IncludeUnschedulableNodes: true,
-	}
+	testController := newTestController(t, `{
+	  "coresPerReplica": 2,
+	  "nodesPerReplica": 2,
+	  "min": 1,
+	  "max": 100,
+	  "preventSinglePointFailure": true,
+	  "includeUnschedulableNodes": true
+	}`)
 
 	testCases := []struct {
 		numSchedulableCores int
@@ -255,7 +342,261 @@ func TestScaleFromUnschedulableNodes(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		if replicas := testController.getExpectedReplicasFromParams(tc.numSchedulableNodes, tc.numSchedulableCores, tc.numNodes, tc.numNodes); tc.expReplicas != replicas {
+		status := &k8sclient.ClusterStatus{
+			SchedulableNodes: int32(tc.numSchedulableNodes),
+			SchedulableCores: int32(tc.numSchedulableCores),
+			TotalNodes:       int32(tc.numNodes),
+			TotalCores:       int32(tc.numNodes),
+		}
+		replicas, err := testController.GetExpectedReplicas(status)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if int32(tc.expReplicas) != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestScaleFromMemory(t *testing.T) {
+	testCases := []struct {
+		clusterStatus             *k8sclient.ClusterStatus
+		includeUnschedulableNodes bool
+		expReplicas               int32
+	}{
+		{
+			&k8sclient.ClusterStatus{},
+			false,
+			1,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 2, SchedulableCores: 4, SchedulableMemory: 8 << 30},
+			false,
+			2,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 2, SchedulableCores: 4, SchedulableMemory: 8<<30 + 1},
+			false,
+			3,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 2, SchedulableCores: 40, SchedulableMemory: 8 << 30},
+			false,
+			10,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 2, SchedulableCores: 4, SchedulableMemory: 8 << 30, TotalMemory: 64 << 30},
+			true,
+			16,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableMemory: 1 << 40},
+			false,
+			100,
+		},
+	}
+
+	for _, tc := range testCases {
+		testController := newTestController(t, fmt.Sprintf(`{
+		  "coresPerReplica": 4,
+		  "memoryPerReplica": "4Gi",
+		  "min": 1,
+		  "max": 100,
+		  "includeUnschedulableNodes": %v
+		}`, tc.includeUnschedulableNodes))
+		replicas, err := testController.GetExpectedReplicas(tc.clusterStatus)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestScaleFromExtendedResources(t *testing.T) {
+	testController := newTestController(t, `{ "nodesPerReplica": 16, "nvidia.com/gpuPerReplica": 8, "min": 1, "max": 100 }`)
+
+	testCases := []struct {
+		clusterStatus *k8sclient.ClusterStatus
+		expError      bool
+		expReplicas   int32
+	}{
+		{
+			&k8sclient.ClusterStatus{
+				SchedulableNodes:             4,
+				SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 0},
+			},
+			false,
+			1,
+		},
+		{
+			&k8sclient.ClusterStatus{
+				SchedulableNodes:             4,
+				SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 32},
+				TotalExtendedResources:       map[string]int64{"nvidia.com/gpu": 64},
+			},
+			false,
+			4,
+		},
+		{
+			&k8sclient.ClusterStatus{
+				SchedulableNodes:             64,
+				SchedulableExtendedResources: map[string]int64{"nvidia.com/gpu": 17},
+			},
+			false,
+			4,
+		},
+		{ // The resource is not counted by the client
+			&k8sclient.ClusterStatus{
+				SchedulableNodes: 4,
+			},
+			true,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		replicas, err := testController.GetExpectedReplicas(tc.clusterStatus)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expected error, got replicas %d for case %v", replicas, tc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestScaleFromPods(t *testing.T) {
+	testController := newTestController(t, `{ "nodesPerReplica": 16, "podsPerReplica": 500, "min": 1, "max": 20 }`)
+
+	testCases := []struct {
+		numNodes    int32
+		numPods     int32
+		expReplicas int32
+	}{
+		{0, 0, 1},
+		{1, 500, 1},
+		{1, 501, 2},
+		{64, 100, 4},
+		{64, 2600, 6},
+		{64, 50000, 20},
+	}
+
+	for _, tc := range testCases {
+		status := &k8sclient.ClusterStatus{SchedulableNodes: tc.numNodes, TotalPods: tc.numPods, PodsCounted: true}
+		replicas, err := testController.GetExpectedReplicas(status)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+	// The pods are not counted without --count-pods.
+	if _, err := testController.GetExpectedReplicas(&k8sclient.ClusterStatus{SchedulableNodes: 1}); err == nil {
+		t.Errorf("Expect error, got no error for podsPerReplica without --count-pods")
+	}
+}
+
+func TestScaleFromServicesAndEndpoints(t *testing.T) {
+	testController := newTestController(t, `{
+	  "nodesPerReplica": 16,
+	  "servicesPerReplica": 100,
+	  "endpointsPerReplica": 1000,
+	  "min": 1,
+	  "max": 20
+	}`)
+
+	testCases := []struct {
+		numNodes     int32
+		numServices  int32
+		numEndpoints int32
+		expReplicas  int32
+	}{
+		{0, 0, 0, 1},
+		{1, 100, 1000, 1},
+		{1, 101, 1000, 2},
+		{1, 100, 3500, 4},
+		{64, 250, 2000, 4},
+		{64, 5000, 0, 20},
+	}
+
+	for _, tc := range testCases {
+		status := &k8sclient.ClusterStatus{
+			SchedulableNodes: tc.numNodes,
+			TotalServices:    tc.numServices,
+			TotalEndpoints:   tc.numEndpoints,
+			ServicesCounted:  true,
+		}
+		replicas, err := testController.GetExpectedReplicas(status)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+	// The services and endpoints are not counted without --count-services.
+	if _, err := testController.GetExpectedReplicas(&k8sclient.ClusterStatus{SchedulableNodes: 1}); err == nil {
+		t.Errorf("Expect error, got no error for servicesPerReplica without --count-services")
+	}
+}
+
+func TestScaleFromSignals(t *testing.T) {
+	testController := newTestController(t, `{ "nodesPerReplica": 16, "routesPerReplica": 50, "min": 1, "max": 100 }`)
+
+	testCases := []struct {
+		clusterStatus *k8sclient.ClusterStatus
+		expError      bool
+		expReplicas   int32
+	}{
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 4, Signals: map[string]float64{"routes": 0}},
+			false,
+			1,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 4, Signals: map[string]float64{"routes": 120}},
+			false,
+			3,
+		},
+		{
+			&k8sclient.ClusterStatus{SchedulableNodes: 160, Signals: map[string]float64{"routes": 120}},
+			false,
+			10,
+		},
+		{ // The signal is not declared
+			&k8sclient.ClusterStatus{SchedulableNodes: 4, Signals: map[string]float64{"ingresses": 120}},
+			true,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		replicas, err := testController.GetExpectedReplicas(tc.clusterStatus)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expected error, got replicas %d for case %v", replicas, tc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if tc.expReplicas != replicas {
 			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
 		}
 	}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package modifier post-processes the replicas computed by any controller.
+package modifier
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&modifiedController{})
+
+// params are the modifiers, read from the params of any mode. The modifiers are
+// the only ones to bound the replicas, an unset min defaults to the minimum of
+// the mode so that modes which may scale to zero keep doing so.
+type params struct {
+	Offset                    int  `json:"offset"`
+	RoundToMultipleOf         int  `json:"roundToMultipleOf"`
+	RoundToOdd                bool `json:"roundToOdd"`
+	Min                       int  `json:"min"`
+	Max                       int  `json:"max"`
+	PreventSinglePointFailure bool `json:"preventSinglePointFailure"`
+	IncludeUnschedulableNodes bool `json:"includeUnschedulableNodes"`
+
+	// MinReplicasPerZone are the replicas to run at least per domain of the
+	// TopologyKey label of the nodes, so that each domain could host some.
+	MinReplicasPerZone int    `json:"minReplicasPerZone"`
+	TopologyKey        string `json:"topologyKey"`
+}
+
+const (
+	// defaultTopologyKey is the topology label whose domains are counted for
+	// minReplicasPerZone by default.
+	defaultTopologyKey = "topology.kubernetes.io/zone"
+)
+
+// parseParams Parse the modifiers from JSON string, defaulting min to defaultMin
+func parseParams(data []byte, defaultMin int) (*params, error) {
+	var p params
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if p.Min < 0 {
+		return nil, fmt.Errorf("invalid negative value for min: %v", p.Min)
+	} else if p.Min == 0 && defaultMin > 0 {
+		glog.V(2).Infof("Defaulting min replicas count to %d", defaultMin)
+		p.Min = defaultMin
+	}
+	if p.Max < 0 {
+		return nil, fmt.Errorf("invalid negative value for max: %v", p.Max)
+	}
+	if p.Max != 0 && p.Max < p.Min {
+		return nil, fmt.Errorf("max replicas count %v should be greater than / equal to min replicas count %v", p.Max, p.Min)
+	}
+	if p.RoundToMultipleOf < 0 {
+		return nil, fmt.Errorf("invalid negative value for roundToMultipleOf: %v", p.RoundToMultipleOf)
+	}
+	if p.RoundToOdd && p.RoundToMultipleOf%2 == 0 && p.RoundToMultipleOf != 0 {
+		return nil, fmt.Errorf("no replicas count is odd and a multiple of the even roundToMultipleOf %v", p.RoundToMultipleOf)
+	}
+	if p.MinReplicasPerZone < 0 {
+		return nil, fmt.Errorf("invalid negative value for minReplicasPerZone: %v", p.MinReplicasPerZone)
+	}
+	if p.TopologyKey != "" && p.MinReplicasPerZone == 0 {
+		return nil, fmt.Errorf("topologyKey requires minReplicasPerZone to be set")
+	} else if p.TopologyKey == "" && p.MinReplicasPerZone > 0 {
+		p.TopologyKey = defaultTopologyKey
+	}
+	if p.Max != 0 && p.roundDown(p.Max) < p.Min {
+		return nil, fmt.Errorf("no replicas count between min %v and max %v satisfies roundToMultipleOf %v and roundToOdd %v", p.Min, p.Max, p.RoundToMultipleOf, p.RoundToOdd)
+	}
+	return &p, nil
+}
+
+// step returns the multiple the replicas are rounded to.
+func (p *params) step() int {
+	if p.RoundToMultipleOf <= 1 {
+		return 1
+	}
+	return p.RoundToMultipleOf
+}
+
+// roundUp returns the smallest rounded replicas count not below replicas. The
+// multiple is odd whenever roundToOdd is set, so the next multiple of an even
+// one is odd.
+func (p *params) roundUp(replicas int) int {
+	step := p.step()
+	res := (replicas + step - 1) / step * step
+	if p.RoundToOdd && res%2 == 0 {
+		res += step
+	}
+	return res
+}
+
+// roundDown returns the largest rounded replicas count not above replicas, or
+// -1 if there is none.
+func (p *params) roundDown(replicas int) int {
+	step := p.step()
+	res := replicas / step * step
+	if p.RoundToOdd && res%2 == 0 {
+		res -= step
+	}
+	if res < 0 {
+		return -1
+	}
+	return res
+}
+
+// apply runs the modifiers in order: offset, rounding, min/max clamping,
+// single point of failure prevention and per-zone minimum. Rounding takes
+// precedence over max only when no rounded count fits, which parseParams
+// rules out.
+func (p *params) apply(replicas int32, nodes int32, zones int32) int32 {
+	res := int(replicas) + p.Offset
+	if res < 0 {
+		res = 0
+	}
+	res = p.roundUp(res)
+	if p.Max != 0 && res > p.Max {
+		res = p.roundDown(p.Max)
+	}
+	if res < p.Min {
+		res = p.roundUp(p.Min)
+	}
+	// Prevent single point of failure by having at least 2 replicas when
+	// there are more than one node, regardless of max.
+	if p.PreventSinglePointFailure && nodes > 1 && res < 2 {
+		res = p.roundUp(2)
+	}
+	// Run at least minReplicasPerZone in each zone, regardless of max as well,
+	// which the topology spread constraints of the target could then enforce.
+	if perZone := int(zones) * p.MinReplicasPerZone; res < perZone {
+		res = p.roundUp(perZone)
+	}
+	return int32(res)
+}
+
+// modifiedController applies the modifiers to the replicas of a controller
+type modifiedController struct {
+	controller.Controller
+	defaultMin int
+	params     *params
+}
+
+// NewController returns a controller applying the modifiers found in the
+// params of cont to its replicas, with min defaulting to defaultMin
+func NewController(cont controller.Controller, defaultMin int) controller.Controller {
+	return &modifiedController{Controller: cont, defaultMin: defaultMin}
+}
+
+func (c *modifiedController) SyncConfig(configMap *v1.ConfigMap) error {
+	params, err := parseParams([]byte(configMap.Data[c.GetControllerType()]), c.defaultMin)
+	if err != nil {
+		return fmt.Errorf("error parsing modifiers: %s", err)
+	}
+	if err := c.Controller.SyncConfig(configMap); err != nil {
+		return err
+	}
+	c.params = params
+	return nil
+}
+
+func (c *modifiedController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	replicas, err := c.Controller.GetExpectedReplicas(status)
+	if err != nil {
+		return 0, err
+	}
+	nodes := status.SchedulableNodes
+	if c.params.IncludeUnschedulableNodes {
+		nodes = status.TotalNodes
+	}
+	var zones int32
+	if c.params.MinReplicasPerZone > 0 {
+		var ok bool
+		zones, ok = status.CountTopologyDomains(c.params.TopologyKey, c.params.IncludeUnschedulableNodes)
+		if !ok {
+			return 0, fmt.Errorf("the %s label is not kept on the nodes to count the zones, use a well-known topology label or set --topology-key", c.params.TopologyKey)
+		}
+		glog.V(4).Infof("Found %d domains of %s", zones, c.params.TopologyKey)
+	}
+	modified := c.params.apply(replicas, nodes, zones)
+	if modified != replicas {
+		glog.V(4).Infof("Modified replicas of %s controller from %d to %d", c.GetControllerType(), replicas, modified)
+	}
+	return modified, nil
+}
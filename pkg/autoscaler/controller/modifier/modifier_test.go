@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/laddercontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestParseParams(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *params
+	}{
+		{
+			`{
+			  "nodesPerReplica": 1,
+			  "offset": -1,
+			  "roundToMultipleOf": 3,
+			  "roundToOdd": true,
+			  "min": 3,
+			  "max": 9,
+			  "preventSinglePointFailure": true,
+			  "includeUnschedulableNodes": true
+			}`,
+			false,
+			&params{
+				Offset:                    -1,
+				RoundToMultipleOf:         3,
+				RoundToOdd:                true,
+				Min:                       3,
+				Max:                       9,
+				PreventSinglePointFailure: true,
+				IncludeUnschedulableNodes: true,
+			},
+		},
+		{ // The zones are counted by default
+			`{ "minReplicasPerZone": 2 }`,
+			false,
+			&params{MinReplicasPerZone: 2, TopologyKey: "topology.kubernetes.io/zone"},
+		},
+		{
+			`{ "minReplicasPerZone": 1, "topologyKey": "kubernetes.io/hostname" }`,
+			false,
+			&params{MinReplicasPerZone: 1, TopologyKey: "kubernetes.io/hostname"},
+		},
+		{ // Min has no default for modes which may scale to zero
+			`{ "nodesToReplicas": [ [ 0, 0 ] ] }`,
+			false,
+			&params{},
+		},
+		{ // Invalid JSON
+			`{ "offset": {{ 1:1 } }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative min
+			`{ "min": -1 }`,
+			true,
+			nil,
+		},
+		{ // Invalid max that smaller than min
+			`{ "min": 5, "max": 4 }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative multiple
+			`{ "roundToMultipleOf": -2 }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative minReplicasPerZone
+			`{ "minReplicasPerZone": -1 }`,
+			true,
+			nil,
+		},
+		{ // topologyKey without minReplicasPerZone
+			`{ "topologyKey": "kubernetes.io/hostname" }`,
+			true,
+			nil,
+		},
+		{ // No odd replicas count between min and max
+			`{ "roundToOdd": true, "min": 4, "max": 4 }`,
+			true,
+			nil,
+		},
+		{ // No multiple of 5 between min and max
+			`{ "roundToMultipleOf": 5, "min": 1, "max": 4 }`,
+			true,
+			nil,
+		},
+		{ // No odd multiple of 2, even without max
+			`{ "roundToOdd": true, "roundToMultipleOf": 2 }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData), 0)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if *params != *tc.expParams {
+			t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", tc.expParams, params)
+		}
+	}
+
+	// An unset min defaults to the minimum of the mode.
+	params, err := parseParams([]byte(`{ "max": 5 }`), 1)
+	if err != nil || params.Min != 1 {
+		t.Errorf("Expected min to default to 1, got %v (error: %v)", params, err)
+	}
+	if _, err := parseParams([]byte(`{ "max": 1 }`), 2); err == nil {
+		t.Errorf("Unexpected parsing success for max below the default min")
+	}
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		params      params
+		replicas    int32
+		nodes       int32
+		zones       int32
+		expReplicas int32
+	}{
+		{params{}, 0, 0, 0, 0},
+		{params{}, 7, 0, 0, 7},
+		{params{Offset: 2}, 3, 0, 0, 5},
+		{params{Offset: -5}, 3, 0, 0, 0},
+		{params{RoundToOdd: true}, 4, 0, 0, 5},
+		{params{RoundToOdd: true}, 5, 0, 0, 5},
+		{params{RoundToMultipleOf: 4}, 5, 0, 0, 8},
+		{params{RoundToMultipleOf: 4}, 0, 0, 0, 0},
+		{params{RoundToMultipleOf: 3, RoundToOdd: true}, 4, 0, 0, 9},
+		{params{RoundToMultipleOf: 5, RoundToOdd: true}, 0, 0, 0, 5},
+		{params{RoundToMultipleOf: 3, RoundToOdd: true, Max: 14}, 10, 0, 0, 9},
+		{params{Min: 2, Max: 10}, 0, 0, 0, 2},
+		{params{Min: 2, Max: 10}, 20, 0, 0, 10},
+		// Rounding up would exceed max, round down instead
+		{params{RoundToOdd: true, Max: 6}, 6, 0, 0, 5},
+		{params{RoundToMultipleOf: 4, Max: 10}, 9, 0, 0, 8},
+		// Rounding min up
+		{params{RoundToMultipleOf: 4, Min: 1}, 0, 0, 0, 4},
+		{params{PreventSinglePointFailure: true}, 1, 1, 0, 1},
+		{params{PreventSinglePointFailure: true}, 1, 2, 0, 2},
+		{params{PreventSinglePointFailure: true, RoundToOdd: true}, 1, 2, 0, 3},
+		// Preventing single point of failure overrides max as in the linear mode
+		{params{PreventSinglePointFailure: true, Max: 1}, 1, 2, 0, 2},
+		{params{MinReplicasPerZone: 2}, 1, 6, 3, 6},
+		{params{MinReplicasPerZone: 2}, 8, 6, 3, 8},
+		{params{MinReplicasPerZone: 1, RoundToOdd: true}, 1, 6, 4, 5},
+		// The per-zone minimum overrides max as well
+		{params{MinReplicasPerZone: 2, Max: 4}, 1, 6, 3, 6},
+	}
+
+	for _, tc := range testCases {
+		if replicas := tc.params.apply(tc.replicas, tc.nodes, tc.zones); replicas != tc.expReplicas {
+			t.Errorf("apply(%d, %d, %d) failed Expected %d, Got %d", tc.replicas, tc.nodes, tc.zones, tc.expReplicas, replicas)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func TestModifiedController(t *testing.T) {
+	// The ladder mode has neither min/max nor single point of failure
+	// prevention of its own.
+	c := NewController(laddercontroller.NewLadderController(), 0)
+	configMap := &v1.ConfigMap{
+		Data: map[string]string{
+			laddercontroller.ControllerType: `{
+			  "nodesToReplicas": [ [ 0, 1 ], [ 4, 4 ], [ 16, 20 ] ],
+			  "roundToOdd": true,
+			  "max": 10,
+			  "preventSinglePointFailure": true
+			}`,
+		},
+	}
+	if err := c.SyncConfig(configMap); err != nil {
+		t.Fatalf("Unexpected sync failure: %v", err)
+	}
+	if c.GetControllerType() != laddercontroller.ControllerType {
+		t.Errorf("Expected controller type %s, Got %s", laddercontroller.ControllerType, c.GetControllerType())
+	}
+
+	testCases := []struct {
+		nodes       int32
+		expReplicas int32
+	}{
+		{1, 1},
+		{2, 3},
+		{4, 5},
+		{16, 9},
+	}
+
+	for _, tc := range testCases {
+		replicas, err := c.GetExpectedReplicas(&k8sclient.ClusterStatus{TotalNodes: tc.nodes, SchedulableNodes: tc.nodes})
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetExpectedReplicas() for %d nodes failed Expected %d, Got %d", tc.nodes, tc.expReplicas, replicas)
+		}
+	}
+
+	// The zones are counted from the topology label of the nodes
+	configMap.Data[laddercontroller.ControllerType] = `{ "nodesToReplicas": [ [ 0, 1 ] ], "minReplicasPerZone": 2 }`
+	if err := c.SyncConfig(configMap); err != nil {
+		t.Fatalf("Unexpected sync failure: %v", err)
+	}
+	status := &k8sclient.ClusterStatus{
+		TotalNodes:       4,
+		SchedulableNodes: 3,
+		TopologyNodes: map[string]map[string]k8sclient.NodeCounts{
+			"topology.kubernetes.io/zone": {
+				"us-east-1a": {Total: 2, Schedulable: 2},
+				"us-east-1b": {Total: 1, Schedulable: 1},
+				"us-east-1c": {Total: 1, Schedulable: 0},
+			},
+		},
+	}
+	if replicas, err := c.GetExpectedReplicas(status); err != nil || replicas != 4 {
+		t.Errorf("GetExpectedReplicas() for 2 zones with schedulable nodes failed Expected 4, Got %d (%v)", replicas, err)
+	}
+	configMap.Data[laddercontroller.ControllerType] = `{ "nodesToReplicas": [ [ 0, 1 ] ], "minReplicasPerZone": 2, "topologyKey": "example.com/rack" }`
+	if err := c.SyncConfig(configMap); err != nil {
+		t.Fatalf("Unexpected sync failure: %v", err)
+	}
+	if _, err := c.GetExpectedReplicas(status); err == nil {
+		t.Errorf("Expect error, got no error for a topology label not kept on the nodes")
+	}
+
+	// Invalid modifiers are rejected along with the params of the mode
+	configMap.Data[laddercontroller.ControllerType] = `{ "nodesToReplicas": [ [ 0, 1 ] ], "roundToMultipleOf": -1 }`
+	if err := c.SyncConfig(configMap); err == nil {
+		t.Errorf("Expect error, got no error for params %s", configMap.Data[laddercontroller.ControllerType])
+	}
+}
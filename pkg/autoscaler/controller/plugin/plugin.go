@@ -22,14 +22,24 @@ import (
 	"k8s.io/api/core/v1"
 
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/capacitycontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/compositecontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/expressioncontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/headroomcontroller"
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/laddercontroller"
 	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/linearcontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/modifier"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/powerlawcontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/ratiocontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/verticalcontroller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
 
 	"github.com/golang/glog"
 )
 
-// EnsureController ensures controller type and scaling params
-func EnsureController(cont controller.Controller, configMap *v1.ConfigMap) (controller.Controller, error) {
+// EnsureController ensures controller type and scaling params, controllers
+// reading other resources do so through k8sClient
+func EnsureController(cont controller.Controller, configMap *v1.ConfigMap, k8sClient k8sclient.K8sClient) (controller.Controller, error) {
 	// Expect only one entry, which uses the name of control mode as the key
 	if len(configMap.Data) != 1 {
 		return nil, fmt.Errorf("invalid configMap format, expected only one entry, got: %v", configMap.Data)
@@ -39,13 +49,10 @@ func EnsureController(cont controller.Controller, configMap *v1.ConfigMap) (cont
 		if cont != nil && mode == cont.GetControllerType() {
 			break
 		}
-		switch mode {
-		case laddercontroller.ControllerType:
-			cont = laddercontroller.NewLadderController()
-		case linearcontroller.ControllerType:
-			cont = linearcontroller.NewLinearController()
-		default:
-			return nil, fmt.Errorf("not a supported control mode: %v", mode)
+		var err error
+		cont, err = newController(mode, k8sClient)
+		if err != nil {
+			return nil, err
 		}
 		glog.V(1).Infof("Set control mode to %v", mode)
 	}
@@ -56,3 +63,42 @@ func EnsureController(cont controller.Controller, configMap *v1.ConfigMap) (cont
 	}
 	return cont, nil
 }
+
+// newController creates the controller of a control mode, applying the
+// modifiers shared by all modes to its replicas if it computes any. The min
+// replicas default to 1, except for the modes which may scale to zero.
+func newController(mode string, k8sClient k8sclient.K8sClient) (controller.Controller, error) {
+	var cont controller.Controller
+	defaultMin := 1
+	switch mode {
+	case laddercontroller.ControllerType:
+		cont = laddercontroller.NewLadderController()
+		defaultMin = 0
+	case linearcontroller.ControllerType:
+		cont = linearcontroller.NewLinearController()
+	case powerlawcontroller.ControllerType:
+		cont = powerlawcontroller.NewPowerLawController()
+	case expressioncontroller.ControllerType:
+		cont = expressioncontroller.NewExpressionController()
+	case compositecontroller.ControllerType:
+		cont = compositecontroller.NewCompositeController(func(mode string) (controller.Controller, error) {
+			if mode == verticalcontroller.ControllerType {
+				return nil, fmt.Errorf("%s mode computes no replicas to combine", mode)
+			}
+			return newController(mode, k8sClient)
+		})
+	case headroomcontroller.ControllerType:
+		cont = headroomcontroller.NewHeadroomController(k8sClient)
+		defaultMin = 0
+	case capacitycontroller.ControllerType:
+		cont = capacitycontroller.NewCapacityController(k8sClient)
+	case ratiocontroller.ControllerType:
+		cont = ratiocontroller.NewRatioController(k8sClient)
+	case verticalcontroller.ControllerType:
+		// The modifiers apply to replicas, not to the resources of a container
+		return verticalcontroller.NewVerticalController(), nil
+	default:
+		return nil, fmt.Errorf("not a supported control mode: %v", mode)
+	}
+	return modifier.NewController(cont, defaultMin), nil
+}
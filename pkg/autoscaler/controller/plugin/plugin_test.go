@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
 )
 
 func TestEnsureController(t *testing.T) {
@@ -52,10 +54,130 @@ func TestEnsureController(t *testing.T) {
 			},
 			false,
 		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"powerlaw": "{\"nodesCoefficient\":1,\"nodesExponent\":0.5}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"powerlaw": "{\"nodesCoefficient\":1}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"expression": "{\"expression\":\"nodes / 4.0\"}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"expression": "{\"expression\":\"nodes / \"}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"composite": "{\"controllers\":[{\"mode\":\"ladder\",\"params\":{\"nodesToReplicas\":[[1,1]]}},{\"mode\":\"linear\",\"params\":{\"coresPerReplica\":16}}]}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"composite": "{\"controllers\":[{\"mode\":\"powerlaw\",\"params\":{\"nodesCoefficient\":1}}]}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"ratio": "{\"source\":\"deployment/frontend\",\"ratio\":0.25}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"ratio": "{\"source\":\"frontend\",\"ratio\":0.25}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"headroom": "{\"headroomPercent\":10,\"cpuPerReplica\":\"1\"}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"headroom": "{\"headroomPercent\":10}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"capacity": "{\"coresFraction\":0.02}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"capacity": "{\"coresFraction\":2}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"vertical": "{\"container\":\"metrics-server\",\"cpu\":{\"base\":\"40m\",\"perNode\":\"1m\"}}",
+				},
+			},
+			false,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"vertical": "{\"cpu\":{\"base\":\"40m\",\"perNode\":\"1m\"}}",
+				},
+			},
+			true,
+		},
+		{
+			&v1.ConfigMap{
+				Data: map[string]string{
+					"composite": "{\"controllers\":[{\"mode\":\"vertical\",\"params\":{\"container\":\"metrics-server\",\"cpu\":{\"base\":\"40m\"}}}]}",
+				},
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
-		_, err := EnsureController(nil, tc.configMap)
+		_, err := EnsureController(nil, tc.configMap, &k8sclient.MockK8sClient{})
 		if err != nil && !tc.expError {
 			t.Errorf("Expect no error, got error for configMap %v, error msg: %v", tc.configMap, err)
 			continue
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package powerlawcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&PowerLawController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "powerlaw"
+)
+
+// PowerLawController uses power-law / logarithmic control pattern
+type PowerLawController struct {
+	params  *powerLawParams
+	version string
+}
+
+// NewPowerLawController returns a new power-law controller
+func NewPowerLawController() controller.Controller {
+	return &PowerLawController{}
+}
+
+type powerLawParams struct {
+	NodesCoefficient          float64 `json:"nodesCoefficient"`
+	NodesExponent             float64 `json:"nodesExponent"`
+	CoresCoefficient          float64 `json:"coresCoefficient"`
+	CoresLogBase              float64 `json:"coresLogBase"`
+	IncludeUnschedulableNodes bool    `json:"includeUnschedulableNodes"`
+}
+
+func (c *PowerLawController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing powerlaw params: %s", err)
+	}
+	c.params = params
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string
+func parseParams(data []byte) (*powerLawParams, error) {
+	var p powerLawParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if p.NodesCoefficient == 0 && p.CoresCoefficient == 0 {
+		return nil, fmt.Errorf("should at least provide either NodesCoefficient or CoresCoefficient (Greater than 0)")
+	}
+	if p.NodesCoefficient < 0 {
+		return nil, fmt.Errorf("invalid negative value for nodesCoefficient: %v", p.NodesCoefficient)
+	}
+	if p.CoresCoefficient < 0 {
+		return nil, fmt.Errorf("invalid negative value for coresCoefficient: %v", p.CoresCoefficient)
+	}
+	if p.NodesCoefficient > 0 && p.NodesExponent <= 0 {
+		return nil, fmt.Errorf("nodesExponent should be greater than 0 when nodesCoefficient is set, got: %v", p.NodesExponent)
+	}
+	if p.CoresCoefficient > 0 && p.CoresLogBase <= 1 {
+		return nil, fmt.Errorf("coresLogBase should be greater than 1 when coresCoefficient is set, got: %v", p.CoresLogBase)
+	}
+	return &p, nil
+}
+
+func (c *PowerLawController) GetParamsVersion() string {
+	return c.version
+}
+
+func (c *PowerLawController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	// Get the expected replicas for the currently number of nodes and cores
+	expReplicas := int32(c.getExpectedReplicasFromParams(int(status.SchedulableNodes), int(status.SchedulableCores), int(status.TotalNodes), int(status.TotalCores)))
+
+	return expReplicas, nil
+}
+
+func (c *PowerLawController) getExpectedReplicasFromParams(schedulableNodes, schedulableCores, totalNodes, totalCores int) int {
+	nodes := schedulableNodes
+	cores := schedulableCores
+	if c.params.IncludeUnschedulableNodes {
+		nodes = totalNodes
+		cores = totalCores
+	}
+	replicasFromCore := c.getExpectedReplicasFromParam(c.params.CoresCoefficient, logarithm(cores, c.params.CoresLogBase))
+	replicasFromNode := c.getExpectedReplicasFromParam(c.params.NodesCoefficient, math.Pow(float64(nodes), c.params.NodesExponent))
+
+	// Returns the results which yields the most replicas
+	if replicasFromCore > replicasFromNode {
+		return replicasFromCore
+	}
+	return replicasFromNode
+}
+
+func (c *PowerLawController) getExpectedReplicasFromParam(coefficient, scaledResources float64) int {
+	if coefficient == 0 {
+		return 1
+	}
+	return int(math.Ceil(coefficient * scaledResources))
+}
+
+// logarithm returns log_base(resources), treating anything below a single
+// resource as zero so that an empty cluster does not yield negative replicas.
+func logarithm(resources int, base float64) float64 {
+	if resources <= 1 || base <= 1 {
+		return 0
+	}
+	return math.Log(float64(resources)) / math.Log(base)
+}
+
+func (c *PowerLawController) GetControllerType() string {
+	return ControllerType
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package powerlawcontroller
+
+import (
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func verifyParams(t *testing.T, scalerParams, expScalerParams *powerLawParams) {
+	if *scalerParams != *expScalerParams {
+		t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", expScalerParams, scalerParams)
+	}
+}
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *powerLawParams
+	}{
+		{
+			`{
+		      "nodesCoefficient": 1.5,
+		      "nodesExponent": 0.5,
+		      "coresCoefficient": 2,
+		      "coresLogBase": 2,
+		      "includeUnschedulableNodes": true
+		    }`,
+			false,
+			&powerLawParams{
+				NodesCoefficient:          1.5,
+				NodesExponent:             0.5,
+				CoresCoefficient:          2,
+				CoresLogBase:              2,
+				IncludeUnschedulableNodes: true,
+			},
+		},
+		{ // Invalid JSON
+			`{ "nodesCoefficient": {{ 1:1 } }`,
+			true,
+			&powerLawParams{},
+		},
+		{ // Both coefficients are unset
+			`{ "min": 1, "max": 100 }`,
+			true,
+			&powerLawParams{},
+		},
+		{ // Invalid negative coefficient
+			`{ "coresCoefficient": -1, "coresLogBase": 2 }`,
+			true,
+			&powerLawParams{},
+		},
+		{ // Missing exponent
+			`{ "nodesCoefficient": 1 }`,
+			true,
+			&powerLawParams{},
+		},
+		{ // Log base must be greater than 1
+			`{ "coresCoefficient": 1, "coresLogBase": 1 }`,
+			true,
+			&powerLawParams{},
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil && !tc.expError {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		verifyParams(t, params, tc.expParams)
+	}
+}
+
+func TestScaleFromNodes(t *testing.T) {
+	testController := &PowerLawController{}
+	testController.params = &powerLawParams{
+		NodesCoefficient: 1,
+		NodesExponent:    0.5,
+	}
+
+	testCases := []struct {
+		numNodes    int
+		expReplicas int
+	}{
+		{0, 1},
+		{1, 1},
+		{4, 2},
+		{5, 3},
+		{16, 4},
+		{100, 10},
+		{101, 11},
+		{5000, 71},
+		{20000, 142},
+	}
+
+	for _, tc := range testCases {
+		if replicas := testController.getExpectedReplicasFromParams(tc.numNodes, 0, tc.numNodes, 0); tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestScaleFromCores(t *testing.T) {
+	testController := &PowerLawController{}
+	testController.params = &powerLawParams{
+		CoresCoefficient: 2,
+		CoresLogBase:     2,
+	}
+
+	testCases := []struct {
+		numCores    int
+		expReplicas int
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{4, 4},
+		{5, 5},
+		{1024, 20},
+		{1025, 21},
+		{1 << 20, 40},
+	}
+
+	for _, tc := range testCases {
+		if replicas := testController.getExpectedReplicasFromParams(0, tc.numCores, 0, tc.numCores); tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestScaleFromMultipleParams(t *testing.T) {
+	testController := &PowerLawController{}
+	testController.params = &powerLawParams{
+		NodesCoefficient: 1,
+		NodesExponent:    0.5,
+		CoresCoefficient: 1,
+		CoresLogBase:     2,
+	}
+
+	testCases := []struct {
+		numCores    int
+		numNodes    int
+		expReplicas int
+	}{
+		{0, 0, 0},
+		{1, 1, 1},
+		{2, 2, 2},
+		{16, 4, 4},
+		{64, 4, 6},
+		{64, 64, 8},
+		{1024, 400, 20},
+	}
+
+	for _, tc := range testCases {
+		if replicas := testController.getExpectedReplicasFromParams(tc.numNodes, tc.numCores, tc.numNodes, tc.numCores); tc.expReplicas != replicas {
+			t.Errorf("Scaler Lookup failed for case %v: Expected %d, Got %d", tc, tc.expReplicas, replicas)
+		}
+	}
+}
+
+func TestScaleFromUnschedulableNodes(t *testing.T) {
+	testcases := []struct {
+		clusterStatus             *k8sclient.ClusterStatus
+		expectedReplicas          int32
+		includeUnschedulableNodes bool
+	}{
+		{
+			clusterStatus: &k8sclient.ClusterStatus{
+				TotalNodes:       16,
+				SchedulableNodes: 9,
+				TotalCores:       64,
+				SchedulableCores: 36,
+			},
+			expectedReplicas:          4,
+			includeUnschedulableNodes: true,
+		},
+		{
+			clusterStatus: &k8sclient.ClusterStatus{
+				TotalNodes:       16,
+				SchedulableNodes: 9,
+				TotalCores:       64,
+				SchedulableCores: 36,
+			},
+			expectedReplicas:          3,
+			includeUnschedulableNodes: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		c := &PowerLawController{
+			params: &powerLawParams{
+				NodesCoefficient:          1,
+				NodesExponent:             0.5,
+				IncludeUnschedulableNodes: tc.includeUnschedulableNodes,
+			},
+		}
+		actualReplicas, err := c.GetExpectedReplicas(tc.clusterStatus)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if tc.expectedReplicas != actualReplicas {
+			t.Errorf("ScaleFromUnschedulableNodes failed Expected %d, Got %d", tc.expectedReplicas, actualReplicas)
+			spew.Dump(tc)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratiocontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&RatioController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "ratio"
+)
+
+// ReplicasGetter reads the replicas of a workload
+type ReplicasGetter interface {
+	// GetReplicas returns the desired or ready replicas of a workload given as
+	// kind/name
+	GetReplicas(workload string, ready bool) (int32, error)
+}
+
+// RatioController scales in proportion to the replicas of another workload
+type RatioController struct {
+	replicasGetter ReplicasGetter
+	params         *ratioParams
+	version        string
+}
+
+// NewRatioController returns a new ratio controller reading the replicas of
+// the source workload with replicasGetter
+func NewRatioController(replicasGetter ReplicasGetter) controller.Controller {
+	return &RatioController{replicasGetter: replicasGetter}
+}
+
+type ratioParams struct {
+	// Source is the workload to follow, as kind/name in the namespace of the
+	// targets (e.g. deployment/frontend).
+	Source string  `json:"source"`
+	Ratio  float64 `json:"ratio"`
+	// ReadyReplicas follows the ready replicas of the source instead of its
+	// desired replicas.
+	ReadyReplicas bool `json:"readyReplicas"`
+}
+
+func (c *RatioController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing ratio params: %s", err)
+	}
+	c.params = params
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string
+func parseParams(data []byte) (*ratioParams, error) {
+	var p ratioParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if splits := strings.Split(p.Source, "/"); len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+		return nil, fmt.Errorf("source should be provided as kind/name, got: %q", p.Source)
+	}
+	if p.Ratio <= 0 {
+		return nil, fmt.Errorf("ratio should be greater than 0, got: %v", p.Ratio)
+	}
+	return &p, nil
+}
+
+func (c *RatioController) GetParamsVersion() string {
+	return c.version
+}
+
+func (c *RatioController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	sourceReplicas, err := c.replicasGetter.GetReplicas(c.params.Source, c.params.ReadyReplicas)
+	if err != nil {
+		return 0, fmt.Errorf("error getting replicas of %s: %v", c.params.Source, err)
+	}
+	glog.V(4).Infof("Replicas of %s: %d", c.params.Source, sourceReplicas)
+	return int32(c.getExpectedReplicasFromSource(sourceReplicas)), nil
+}
+
+func (c *RatioController) getExpectedReplicasFromSource(sourceReplicas int32) int {
+	return int(math.Ceil(controller.RoundOff(float64(sourceReplicas) * c.params.Ratio)))
+}
+
+func (c *RatioController) GetControllerType() string {
+	return ControllerType
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratiocontroller
+
+import (
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData  string
+		expError  bool
+		expParams *ratioParams
+	}{
+		{
+			`{
+			  "source": "deployment/frontend",
+			  "ratio": 0.25,
+			  "readyReplicas": true,
+			  "min": 2,
+			  "max": 10
+			}`,
+			false,
+			&ratioParams{
+				Source:        "deployment/frontend",
+				Ratio:         0.25,
+				ReadyReplicas: true,
+			},
+		},
+		{ // Invalid JSON
+			`{ "source": {{ 1:1 } }`,
+			true,
+			nil,
+		},
+		{ // Missing source
+			`{ "ratio": 0.25 }`,
+			true,
+			nil,
+		},
+		{ // Source without kind
+			`{ "source": "frontend", "ratio": 0.25 }`,
+			true,
+			nil,
+		},
+		{ // Missing ratio
+			`{ "source": "deployment/frontend" }`,
+			true,
+			nil,
+		},
+		{ // Invalid negative ratio
+			`{ "source": "deployment/frontend", "ratio": -1 }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Unexpected parsing success. Expected failure")
+				spew.Dump(tc)
+				spew.Dump(params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if *params != *tc.expParams {
+			t.Errorf("Parser error - Expected params %v MISMATCHED: Got %v", tc.expParams, params)
+		}
+	}
+}
+
+func TestScaleFromSource(t *testing.T) {
+	mockK8s := &k8sclient.MockK8sClient{
+		WorkloadReplicas:      map[string]int32{"deployment/frontend": 30},
+		ReadyWorkloadReplicas: map[string]int32{"deployment/frontend": 13},
+	}
+
+	testCases := []struct {
+		params      ratioParams
+		expError    bool
+		expReplicas int32
+	}{
+		{ratioParams{Source: "deployment/frontend", Ratio: 0.25}, false, 8},
+		{ratioParams{Source: "deployment/frontend", Ratio: 0.25, ReadyReplicas: true}, false, 4},
+		// Floating point errors do not add a replica
+		{ratioParams{Source: "deployment/frontend", Ratio: 0.1}, false, 3},
+		{ratioParams{Source: "deployment/frontend", Ratio: 2}, false, 60},
+		{ratioParams{Source: "deployment/frontend", Ratio: 0.01}, false, 1},
+		{ratioParams{Source: "deployment/backend", Ratio: 1}, true, 0},
+	}
+
+	for _, tc := range testCases {
+		params := tc.params
+		c := &RatioController{replicasGetter: mockK8s, params: &params}
+		replicas, err := c.GetExpectedReplicas(&k8sclient.ClusterStatus{})
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for params %+v", tc.params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			spew.Dump(tc)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetExpectedReplicas() failed Expected %d, Got %d", tc.expReplicas, replicas)
+			spew.Dump(tc)
+		}
+	}
+}
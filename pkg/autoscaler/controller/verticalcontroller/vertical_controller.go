@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verticalcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+var _ = controller.Controller(&VerticalController{})
+var _ = controller.Resizer(&VerticalController{})
+
+const (
+	// ControllerType defines the controller type string
+	ControllerType = "vertical"
+)
+
+// VerticalController resizes a container of the target in proportion to the
+// number of nodes, rather than scaling its replicas
+type VerticalController struct {
+	params  *verticalParams
+	version string
+}
+
+// NewVerticalController returns a new vertical controller
+func NewVerticalController() controller.Controller {
+	return &VerticalController{}
+}
+
+type verticalParams struct {
+	// Container is the name of the container to resize.
+	Container string          `json:"container"`
+	CPU       *resourceParams `json:"cpu"`
+	Memory    *resourceParams `json:"memory"`
+	// TolerancePercent is the difference, as a percentage of the expected
+	// resources, within which the current resources are left as is.
+	TolerancePercent          float64 `json:"tolerancePercent"`
+	IncludeUnschedulableNodes bool    `json:"includeUnschedulableNodes"`
+}
+
+type resourceParams struct {
+	Base    string `json:"base"`
+	PerNode string `json:"perNode"`
+	// Max caps the request, no cap if empty.
+	Max string `json:"max"`
+	// SetLimit sets the limit along with the request to the same amount.
+	SetLimit bool `json:"setLimit"`
+
+	// base, perNode and max are the parsed quantities.
+	base, perNode, max resource.Quantity
+}
+
+func (c *VerticalController) SyncConfig(configMap *v1.ConfigMap) error {
+	glog.V(0).Infof("ConfigMap version change (old: %s new: %s) - rebuilding params", c.version, configMap.ObjectMeta.ResourceVersion)
+	glog.V(2).Infof("Params from apiserver: \n%v", configMap.Data[ControllerType])
+	params, err := parseParams([]byte(configMap.Data[ControllerType]))
+	if err != nil {
+		return fmt.Errorf("error parsing vertical params: %s", err)
+	}
+	c.params = params
+	c.version = configMap.ObjectMeta.ResourceVersion
+	return nil
+}
+
+// parseParams Parse the params from JSON string
+func parseParams(data []byte) (*verticalParams, error) {
+	var p verticalParams
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse parameters (%s)", err)
+	}
+	if p.Container == "" {
+		return nil, fmt.Errorf("container should be provided")
+	}
+	if p.CPU == nil && p.Memory == nil {
+		return nil, fmt.Errorf("should at least provide either cpu or memory")
+	}
+	if p.TolerancePercent < 0 || p.TolerancePercent >= 100 {
+		return nil, fmt.Errorf("tolerancePercent should be between 0 and 100, got: %v", p.TolerancePercent)
+	}
+	if p.CPU != nil {
+		if err := p.CPU.parse(); err != nil {
+			return nil, fmt.Errorf("invalid cpu params: %v", err)
+		}
+	}
+	if p.Memory != nil {
+		if err := p.Memory.parse(); err != nil {
+			return nil, fmt.Errorf("invalid memory params: %v", err)
+		}
+	}
+	return &p, nil
+}
+
+func (r *resourceParams) parse() error {
+	for _, q := range []struct {
+		name     string
+		value    string
+		quantity *resource.Quantity
+	}{
+		{"base", r.Base, &r.base},
+		{"perNode", r.PerNode, &r.perNode},
+		{"max", r.Max, &r.max},
+	} {
+		if q.value == "" {
+			continue
+		}
+		parsed, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return fmt.Errorf("invalid quantity for %s %q: %v", q.name, q.value, err)
+		}
+		if parsed.Sign() < 0 {
+			return fmt.Errorf("invalid negative value for %s: %v", q.name, q.value)
+		}
+		*q.quantity = parsed
+	}
+	if r.base.IsZero() && r.perNode.IsZero() {
+		return fmt.Errorf("should at least provide either base or perNode (Greater than 0)")
+	}
+	if r.Max != "" && r.max.Cmp(r.base) < 0 {
+		return fmt.Errorf("max %v should be greater than / equal to base %v", r.Max, r.Base)
+	}
+	return nil
+}
+
+func (c *VerticalController) GetParamsVersion() string {
+	return c.version
+}
+
+// GetExpectedReplicas is not supported, the vertical controller leaves the
+// replicas of the target as is
+func (c *VerticalController) GetExpectedReplicas(status *k8sclient.ClusterStatus) (int32, error) {
+	return 0, fmt.Errorf("%s mode resizes a container and computes no replicas", ControllerType)
+}
+
+func (c *VerticalController) GetExpectedResources(status *k8sclient.ClusterStatus) (string, v1.ResourceRequirements, float64, error) {
+	nodes := int64(status.SchedulableNodes)
+	if c.params.IncludeUnschedulableNodes {
+		nodes = int64(status.TotalNodes)
+	}
+	resources := v1.ResourceRequirements{Requests: v1.ResourceList{}}
+	if c.params.CPU != nil {
+		cpu := c.params.CPU
+		request := resource.NewMilliQuantity(cpu.base.MilliValue()+cpu.perNode.MilliValue()*nodes, resource.DecimalSI)
+		c.setResource(&resources, v1.ResourceCPU, *request, cpu)
+	}
+	if c.params.Memory != nil {
+		memory := c.params.Memory
+		request := resource.NewQuantity(memory.base.Value()+memory.perNode.Value()*nodes, resource.BinarySI)
+		c.setResource(&resources, v1.ResourceMemory, *request, memory)
+	}
+	return c.params.Container, resources, c.params.TolerancePercent / 100, nil
+}
+
+func (c *VerticalController) setResource(resources *v1.ResourceRequirements, name v1.ResourceName, request resource.Quantity, params *resourceParams) {
+	if params.Max != "" && request.Cmp(params.max) > 0 {
+		request = params.max
+	}
+	resources.Requests[name] = request
+	if params.SetLimit {
+		if resources.Limits == nil {
+			resources.Limits = v1.ResourceList{}
+		}
+		resources.Limits[name] = request
+	}
+}
+
+func (c *VerticalController) GetControllerType() string {
+	return ControllerType
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verticalcontroller
+
+import (
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestControllerParser(t *testing.T) {
+	testCases := []struct {
+		jsonData string
+		expError bool
+	}{
+		{
+			`{
+			  "container": "metrics-server",
+			  "cpu": { "base": "40m", "perNode": "1m", "max": "1", "setLimit": true },
+			  "memory": { "base": "40Mi", "perNode": "4Mi" },
+			  "tolerancePercent": 10
+			}`,
+			false,
+		},
+		{ // Only per node increments
+			`{ "container": "metrics-server", "memory": { "perNode": "4Mi" } }`,
+			false,
+		},
+		{ // Invalid JSON
+			`{ "container": {{ 1:1 } }`,
+			true,
+		},
+		{ // Container is unset
+			`{ "cpu": { "base": "40m", "perNode": "1m" } }`,
+			true,
+		},
+		{ // Both resources are unset
+			`{ "container": "metrics-server" }`,
+			true,
+		},
+		{ // Neither base nor per node increments
+			`{ "container": "metrics-server", "cpu": { "max": "1" } }`,
+			true,
+		},
+		{ // Invalid quantity
+			`{ "container": "metrics-server", "cpu": { "base": "forty" } }`,
+			true,
+		},
+		{ // Invalid negative quantity
+			`{ "container": "metrics-server", "memory": { "base": "40Mi", "perNode": "-4Mi" } }`,
+			true,
+		},
+		{ // Max below base
+			`{ "container": "metrics-server", "cpu": { "base": "40m", "max": "20m" } }`,
+			true,
+		},
+		{ // Invalid tolerance
+			`{ "container": "metrics-server", "cpu": { "base": "40m" }, "tolerancePercent": 100 }`,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if tc.expError && err == nil {
+			t.Errorf("Unexpected parsing success. Expected failure")
+			spew.Dump(tc)
+			spew.Dump(params)
+		}
+		if !tc.expError && err != nil {
+			t.Errorf("Unexpected parse failure: %v", err)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func TestGetExpectedResources(t *testing.T) {
+	testCases := []struct {
+		jsonData     string
+		nodes        int32
+		expRequests  v1.ResourceList
+		expLimits    v1.ResourceList
+		expTolerance float64
+	}{
+		{
+			`{
+			  "container": "metrics-server",
+			  "cpu": { "base": "40m", "perNode": "1m" },
+			  "memory": { "base": "40Mi", "perNode": "4Mi" },
+			  "tolerancePercent": 10
+			}`,
+			10,
+			v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m"), v1.ResourceMemory: resource.MustParse("80Mi")},
+			nil,
+			0.1,
+		},
+		{ // The requests are capped, and the limits follow them
+			`{
+			  "container": "metrics-server",
+			  "cpu": { "base": "100m", "perNode": "10m", "max": "1", "setLimit": true }
+			}`,
+			1000,
+			v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			0,
+		},
+		{ // Memory only, on an empty cluster
+			`{ "container": "metrics-server", "memory": { "base": "40Mi", "perNode": "4Mi", "setLimit": true } }`,
+			0,
+			v1.ResourceList{v1.ResourceMemory: resource.MustParse("40Mi")},
+			v1.ResourceList{v1.ResourceMemory: resource.MustParse("40Mi")},
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parseParams([]byte(tc.jsonData))
+		if err != nil {
+			t.Fatalf("Unexpected parse failure: %v", err)
+		}
+		c := &VerticalController{params: params}
+		container, resources, tolerance, err := c.GetExpectedResources(&k8sclient.ClusterStatus{TotalNodes: tc.nodes, SchedulableNodes: tc.nodes})
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if container != "metrics-server" || tolerance != tc.expTolerance {
+			t.Errorf("GetExpectedResources() failed Expected container metrics-server with tolerance %v, Got %s with %v", tc.expTolerance, container, tolerance)
+		}
+		if !equalResources(resources.Requests, tc.expRequests) || !equalResources(resources.Limits, tc.expLimits) {
+			t.Errorf("GetExpectedResources() failed Expected requests %v limits %v, Got requests %v limits %v", tc.expRequests, tc.expLimits, resources.Requests, resources.Limits)
+			spew.Dump(tc)
+		}
+	}
+}
+
+func equalResources(a, b v1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, quantity := range a {
+		if quantity.Cmp(b[name]) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/golang/glog"
+)
+
+func (k *k8sClient) UpdateContainerResources(container string, resources v1.ResourceRequirements, tolerance float64) error {
+	for _, target := range k.scaleTargets.targets {
+		if err := k.updateTargetContainerResources(container, resources, tolerance, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *k8sClient) updateTargetContainerResources(container string, resources v1.ResourceRequirements, tolerance float64, target target) error {
+	kind := strings.ToLower(target.kind)
+	if kind != "deployment" && kind != "deployments" {
+		return fmt.Errorf("unsupported target kind for resizing a container: %v", target.kind)
+	}
+	deployments := k.clientset.AppsV1().Deployments(k.scaleTargets.namespace)
+	deployment, err := deployments.Get(context.TODO(), target.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	var current *v1.ResourceRequirements
+	for i := range deployment.Spec.Template.Spec.Containers {
+		if c := &deployment.Spec.Template.Spec.Containers[i]; c.Name == container {
+			current = &c.Resources
+			break
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("container %s not found in %s/%s", container, target.kind, target.name)
+	}
+	resources = raiseLimits(*current, resources)
+	if withinTolerance(current.Requests, resources.Requests, tolerance) && withinTolerance(current.Limits, resources.Limits, tolerance) {
+		return nil
+	}
+
+	glog.V(0).Infof(
+		"Cluster status: SchedulableNodes[%v], TotalNodes[%v]",
+		k.clusterStatus.SchedulableNodes,
+		k.clusterStatus.TotalNodes)
+	glog.V(0).Infof("Resources are not as expected : updating container %s of %s/%s from requests %v limits %v to requests %v limits %v",
+		container,
+		target.kind,
+		target.name,
+		current.Requests,
+		current.Limits,
+		resources.Requests,
+		resources.Limits)
+	// The containers are merged by name, and only the given resources are set.
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":      container,
+							"resources": resources,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = deployments.Patch(context.TODO(), target.name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// raiseLimits returns the resources with the current limits which are not set
+// and lie below the requests raised to the requests, as the API server rejects
+// requests greater than the limits.
+func raiseLimits(current, resources v1.ResourceRequirements) v1.ResourceRequirements {
+	resources = *resources.DeepCopy()
+	for name, request := range resources.Requests {
+		if _, ok := resources.Limits[name]; ok {
+			continue
+		}
+		if limit, ok := current.Limits[name]; ok && limit.Cmp(request) < 0 {
+			glog.V(2).Infof("Raising the %s limit from %v to the request %v", name, limit.String(), request.String())
+			if resources.Limits == nil {
+				resources.Limits = v1.ResourceList{}
+			}
+			resources.Limits[name] = request
+		}
+	}
+	return resources
+}
+
+// withinTolerance returns whether each of the expected resources is set in
+// current, and differs from it by no more than the tolerance relative to the
+// expected amount.
+func withinTolerance(current, expected v1.ResourceList, tolerance float64) bool {
+	for name, quantity := range expected {
+		currentQuantity, ok := current[name]
+		if !ok {
+			return false
+		}
+		exp := quantity.AsApproximateFloat64()
+		if math.Abs(currentQuantity.AsApproximateFloat64()-exp) > exp*tolerance {
+			return false
+		}
+	}
+	return true
+}
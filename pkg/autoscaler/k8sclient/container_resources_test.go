@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUpdateContainerResources(t *testing.T) {
+	current := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("100m"),
+			v1.ResourceMemory: resource.MustParse("100Mi"),
+		},
+		Limits: v1.ResourceList{
+			v1.ResourceMemory: resource.MustParse("100Mi"),
+		},
+	}
+
+	testCases := []struct {
+		target       string
+		container    string
+		resources    v1.ResourceRequirements
+		tolerance    float64
+		expError     bool
+		expResources v1.ResourceRequirements
+	}{
+		{ // Within tolerance, left as is
+			"deployment/metrics-server",
+			"server",
+			v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("105m")}},
+			0.1,
+			false,
+			current,
+		},
+		{ // Only the given resources are set
+			"deployment/metrics-server",
+			"server",
+			v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("150m")}},
+			0.1,
+			false,
+			v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("150m"),
+					v1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+				Limits: v1.ResourceList{
+					v1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+			},
+		},
+		{ // A missing limit is out of tolerance
+			"deployment/metrics-server",
+			"server",
+			v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			},
+			0.1,
+			false,
+			v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+			},
+		},
+		{ // A current limit below the request is raised to it
+			"deployment/metrics-server",
+			"server",
+			v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("200Mi")}},
+			0.1,
+			false,
+			v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("200Mi"),
+				},
+				Limits: v1.ResourceList{
+					v1.ResourceMemory: resource.MustParse("200Mi"),
+				},
+			},
+		},
+		{ // A current limit above the request is left as is
+			"deployment/metrics-server",
+			"server",
+			v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("50Mi")}},
+			0.1,
+			false,
+			v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("50Mi"),
+				},
+				Limits: v1.ResourceList{
+					v1.ResourceMemory: resource.MustParse("100Mi"),
+				},
+			},
+		},
+		{ // Missing container
+			"deployment/metrics-server",
+			"other",
+			v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("150m")}},
+			0,
+			true,
+			v1.ResourceRequirements{},
+		},
+		{ // Only deployments are resized
+			"statefulset/metrics-server",
+			"server",
+			v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("150m")}},
+			0,
+			true,
+			v1.ResourceRequirements{},
+		},
+	}
+
+	for _, tc := range testCases {
+		client := fake.NewSimpleClientset(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "metrics-server", Namespace: "kube-system"},
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{
+							{Name: "server", Resources: *current.DeepCopy()},
+							{Name: "sidecar"},
+						},
+					},
+				},
+			},
+		})
+		targets, err := getScaleTargets(tc.target, "kube-system")
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := &k8sClient{clientset: client, scaleTargets: targets, clusterStatus: &ClusterStatus{}}
+		err = k.UpdateContainerResources(tc.container, tc.resources, tc.tolerance)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for container %s of %s", tc.container, tc.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		deployment, err := client.AppsV1().Deployments("kube-system").Get(context.TODO(), "metrics-server", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		containers := deployment.Spec.Template.Spec.Containers
+		if len(containers) != 2 || containers[0].Name != "server" {
+			t.Fatalf("Unexpected containers after update: %v", containers)
+		}
+		got := containers[0].Resources
+		if !equalResourceLists(got.Requests, tc.expResources.Requests) || !equalResourceLists(got.Limits, tc.expResources.Limits) {
+			t.Errorf("UpdateContainerResources(%v) set %v, want %v", tc.resources, got, tc.expResources)
+		}
+	}
+}
+
+func equalResourceLists(a, b v1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, quantity := range a {
+		if quantity.Cmp(b[name]) != 0 {
+			return false
+		}
+	}
+	return true
+}
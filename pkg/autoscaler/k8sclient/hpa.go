@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/golang/glog"
+)
+
+func (k *k8sClient) GetTargetHPAs() ([]string, error) {
+	hpas, err := k.clientset.AutoscalingV2().HorizontalPodAutoscalers(k.scaleTargets.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		for _, target := range k.scaleTargets.targets {
+			// Targets are given as kind/name in lower case, possibly plural
+			if ref.Name == target.name && strings.EqualFold(ref.Kind, strings.TrimSuffix(target.kind, "s")) {
+				names = append(names, hpa.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+func (k *k8sClient) UpdateHPAReplicas(name string, minReplicas, maxReplicas int32) error {
+	hpas := k.clientset.AutoscalingV2().HorizontalPodAutoscalers(k.scaleTargets.namespace)
+	hpa, err := hpas.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// The HPA rejects a minReplicas of 0 unless the HPAScaleToZero feature gate
+	// is enabled, while the modifiers let the replicas go down to 0.
+	if minReplicas < 1 {
+		glog.V(2).Infof("Expected minReplicas %d of HorizontalPodAutoscaler %s is below 1, raising to 1", minReplicas, name)
+		minReplicas = 1
+	}
+	if maxReplicas == 0 {
+		maxReplicas = hpa.Spec.MaxReplicas
+		// The HPA rejects a minReplicas above its maxReplicas, which is left as
+		// the upper bound
+		if minReplicas > maxReplicas {
+			glog.Warningf("Expected minReplicas %d of HorizontalPodAutoscaler %s is above its maxReplicas, capping to %d", minReplicas, name, maxReplicas)
+			minReplicas = maxReplicas
+		}
+	}
+	var prevMinReplicas int32 = 1
+	if hpa.Spec.MinReplicas != nil {
+		prevMinReplicas = *hpa.Spec.MinReplicas
+	}
+	if minReplicas == prevMinReplicas && maxReplicas == hpa.Spec.MaxReplicas {
+		return nil
+	}
+
+	glog.V(0).Infof(
+		"Cluster status: SchedulableNodes[%v], TotalNodes[%v], SchedulableCores[%v], TotalCores[%v], SchedulableMemory[%v], TotalMemory[%v]",
+		k.clusterStatus.SchedulableNodes,
+		k.clusterStatus.TotalNodes,
+		k.clusterStatus.SchedulableCores,
+		k.clusterStatus.TotalCores,
+		k.clusterStatus.SchedulableMemory,
+		k.clusterStatus.TotalMemory)
+	glog.V(0).Infof("Replicas are not as expected : updating HorizontalPodAutoscaler %s from minReplicas %d maxReplicas %d to minReplicas %d maxReplicas %d",
+		name,
+		prevMinReplicas,
+		hpa.Spec.MaxReplicas,
+		minReplicas,
+		maxReplicas)
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"minReplicas": minReplicas,
+			"maxReplicas": maxReplicas,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = hpas.Patch(context.TODO(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
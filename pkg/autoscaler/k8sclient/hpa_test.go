@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newHPA(name, namespace, kind, target string, minReplicas, maxReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: kind, Name: target, APIVersion: "apps/v1"},
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    maxReplicas,
+		},
+	}
+}
+
+func TestGetTargetHPAs(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newHPA("web", "test-namespace", "Deployment", "web", 1, 10),
+		newHPA("other", "test-namespace", "Deployment", "other", 1, 10),
+		newHPA("web-rs", "test-namespace", "ReplicaSet", "web", 1, 10),
+		newHPA("web-elsewhere", "default", "Deployment", "web", 1, 10),
+	)
+
+	testCases := []struct {
+		target  string
+		expHPAs []string
+	}{
+		{"deployment/web", []string{"web"}},
+		{"deployment/web,replicaset/web", []string{"web", "web-rs"}},
+		{"deployment/api", nil},
+	}
+
+	for _, tc := range testCases {
+		targets, err := getScaleTargets(tc.target, "test-namespace")
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := &k8sClient{clientset: client, scaleTargets: targets}
+		hpas, err := k.GetTargetHPAs()
+		if err != nil {
+			t.Errorf("Unexpected error for target %s: %v", tc.target, err)
+			continue
+		}
+		if !reflect.DeepEqual(hpas, tc.expHPAs) {
+			t.Errorf("GetTargetHPAs()=%v, want %v for target %s", hpas, tc.expHPAs, tc.target)
+		}
+	}
+}
+
+func TestUpdateHPAReplicas(t *testing.T) {
+	testCases := []struct {
+		minReplicas    int32
+		maxReplicas    int32
+		expMinReplicas int32
+		expMaxReplicas int32
+	}{
+		{5, 0, 5, 10},
+		{5, 20, 5, 20},
+		// Capped to the maxReplicas left as is
+		{15, 0, 10, 10},
+		{15, 30, 15, 30},
+		// Raised to 1 as the HPA cannot scale to zero
+		{0, 0, 1, 10},
+	}
+
+	for _, tc := range testCases {
+		client := fake.NewSimpleClientset(newHPA("web", "test-namespace", "Deployment", "web", 2, 10))
+		targets, err := getScaleTargets("deployment/web", "test-namespace")
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := &k8sClient{clientset: client, scaleTargets: targets, clusterStatus: &ClusterStatus{}}
+		if err := k.UpdateHPAReplicas("web", tc.minReplicas, tc.maxReplicas); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		hpa, err := client.AutoscalingV2().HorizontalPodAutoscalers("test-namespace").Get(context.TODO(), "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *hpa.Spec.MinReplicas != tc.expMinReplicas || hpa.Spec.MaxReplicas != tc.expMaxReplicas {
+			t.Errorf("UpdateHPAReplicas(%d, %d) set minReplicas %d maxReplicas %d, want %d and %d",
+				tc.minReplicas, tc.maxReplicas, *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas, tc.expMinReplicas, tc.expMaxReplicas)
+		}
+	}
+
+	targets, _ := getScaleTargets("deployment/web", "test-namespace")
+	k := &k8sClient{clientset: fake.NewSimpleClientset(), scaleTargets: targets, clusterStatus: &ClusterStatus{}}
+	if err := k.UpdateHPAReplicas("web", 5, 0); err == nil {
+		t.Errorf("Expect error for a missing HorizontalPodAutoscaler, got no error")
+	}
+}
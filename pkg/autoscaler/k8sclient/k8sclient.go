@@ -19,18 +19,24 @@ package k8sclient
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
 	"k8s.io/client-go/rest"
 
 	"github.com/golang/glog"
@@ -44,31 +50,102 @@ type K8sClient interface {
 	CreateConfigMap(namespace, configmap string, params map[string]string) (*v1.ConfigMap, error)
 	// UpdateConfigMap updates a configmap with given namespace, name and params
 	UpdateConfigMap(namespace, configmap string, params map[string]string) (*v1.ConfigMap, error)
+	// UpdateConfigMapData replaces the data of a fetched configmap, keeping
+	// its metadata
+	UpdateConfigMapData(configMap *v1.ConfigMap, data map[string]string) (*v1.ConfigMap, error)
 	// GetClusterStatus counts schedulable nodes and cores in the cluster
 	GetClusterStatus() (clusterStatus *ClusterStatus, err error)
 	// GetNamespace returns the namespace of target resource.
 	GetNamespace() (namespace string)
-	// UpdateReplicas updates the number of replicas for the resource and return the previous replicas count
+	// UpdateReplicas updates the number of replicas for the resource and return the previous replicas count,
+	// weighted targets are updated with their share of the replicas
 	UpdateReplicas(expReplicas int32) (err error)
+	// GetReplicas returns the desired or ready replicas of a workload given as
+	// kind/name in the namespace of the targets
+	GetReplicas(workload string, ready bool) (int32, error)
+	// GetTargetPodRequests returns the cpu and memory requests of a pod of the
+	// first target, as read from its pod template
+	GetTargetPodRequests() (v1.ResourceList, error)
+	// GetTargetRequests returns the summed cpu and memory requests of the
+	// scheduled pods of the targets, as counted in the cluster status
+	GetTargetRequests() (v1.ResourceList, error)
+	// UpdateContainerResources sets the resources of a container of the
+	// targets, unless they are within tolerance of the given ones
+	UpdateContainerResources(container string, resources v1.ResourceRequirements, tolerance float64) error
+	// GetTargetHPAs returns the names of the HorizontalPodAutoscalers scaling
+	// any of the targets
+	GetTargetHPAs() ([]string, error)
+	// UpdateHPAReplicas updates the minReplicas and maxReplicas of a
+	// HorizontalPodAutoscaler in the namespace of the targets, minReplicas is
+	// raised to 1 and maxReplicas is left as is if 0
+	UpdateHPAReplicas(name string, minReplicas, maxReplicas int32) error
+	// UpdatePodDisruptionBudgets creates or updates a PodDisruptionBudget for
+	// each target, with either minAvailable or maxUnavailable set by budget
+	// from the replicas of the target
+	UpdatePodDisruptionBudgets(expReplicas int32, budget func(replicas int32) (minAvailable, maxUnavailable *intstr.IntOrString)) error
+	// GetTargets returns the targets as kind/name
+	GetTargets() []string
+	// ForTarget returns a client sharing the cluster status and informers of
+	// this one, scaling only the given target to the replicas as is
+	ForTarget(workload string) (K8sClient, error)
+	// GetTargetTopologyDomains returns the topology domain of each zonal
+	// target keyed by kind/name, nil if the targets are not zonal
+	GetTargetTopologyDomains() map[string]string
+	// GetTargetWeights returns the weight of each weighted target keyed by
+	// kind/name, nil if the targets are not weighted
+	GetTargetWeights() map[string]int32
 }
 
 // k8sClient - Wraps all Kubernetes API client functionalities
 type k8sClient struct {
-	scaleTargets  *scaleTargets
-	clientset     kubernetes.Interface
-	clusterStatus *ClusterStatus
-	nodeLister    corelisters.NodeLister
-	stopCh        chan struct{}
+	scaleTargets        *scaleTargets
+	clientset           kubernetes.Interface
+	clusterStatus       *ClusterStatus
+	nodeLister          corelisters.NodeLister
+	podLister           corelisters.PodLister
+	requestPodLister    corelisters.PodLister
+	requestOptions      *RequestCounterOptions
+	serviceLister       corelisters.ServiceLister
+	endpointSliceLister discoverylisters.EndpointSliceLister
+	extendedResources   []v1.ResourceName
+	stopCh              chan struct{}
+
+	// topologyKey is the label of the nodes bucketing them into topology
+	// domains, and topologyKeys all the topology labels kept on the nodes.
+	topologyKey  string
+	topologyKeys []string
+}
+
+// wellKnownTopologyKeys are the topology labels kept on the nodes along with
+// the configured topology key, whose domains are counted.
+var wellKnownTopologyKeys = []string{v1.LabelTopologyZone, v1.LabelTopologyRegion, v1.LabelHostname}
+
+// PodCounterOptions configures the optional pod informer used to count pods.
+type PodCounterOptions struct {
+	// Namespace restricts the counted pods to a namespace, all namespaces if empty.
+	Namespace string
+	// LabelSelector restricts the counted pods to the ones matching the selector.
+	LabelSelector string
 }
 
-func getTrimmedNodeClients(clientset kubernetes.Interface, labelOptions informers.SharedInformerOption) (informers.SharedInformerFactory, corelisters.NodeLister, error) {
+func getTrimmedNodeClients(clientset kubernetes.Interface, labelOptions informers.SharedInformerOption, topologyKeys []string) (informers.SharedInformerFactory, corelisters.NodeLister, error) {
 	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, labelOptions)
 	nodeInformer := factory.Core().V1().Nodes().Informer()
 	err := nodeInformer.SetTransform(func(obj any) (any, error) {
 		// Trimming unneeded fields to reduce memory consumption under large-scale.
 		if node, ok := obj.(*v1.Node); ok {
+			var labels map[string]string
+			for _, key := range topologyKeys {
+				if domain, ok := node.Labels[key]; ok {
+					if labels == nil {
+						labels = make(map[string]string, len(topologyKeys))
+					}
+					labels[key] = domain
+				}
+			}
 			node.ObjectMeta = metav1.ObjectMeta{
-				Name: node.Name,
+				Name:   node.Name,
+				Labels: labels,
 			}
 			node.Spec = v1.NodeSpec{
 				Unschedulable: node.Spec.Unschedulable,
@@ -87,44 +164,202 @@ func getTrimmedNodeClients(clientset kubernetes.Interface, labelOptions informer
 	return factory, nodeLister, nil
 }
 
-// NewK8sClient gives a k8sClient with the given dependencies.
-func NewK8sClient(clientset kubernetes.Interface, namespace, target string, nodelabels string) (K8sClient, error) {
+func getTrimmedPodClients(clientset kubernetes.Interface, options ...informers.SharedInformerOption) (informers.SharedInformerFactory, corelisters.PodLister, error) {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, options...)
+	podInformer := factory.Core().V1().Pods().Informer()
+	err := podInformer.SetTransform(func(obj any) (any, error) {
+		// Trimming unneeded fields to reduce memory consumption under large-scale.
+		if pod, ok := obj.(*v1.Pod); ok {
+			pod.ObjectMeta = metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			}
+			pod.Spec = v1.PodSpec{}
+			pod.Status = v1.PodStatus{
+				Phase: pod.Status.Phase,
+			}
+		}
+		return obj, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	podLister := factory.Core().V1().Pods().Lister()
+	return factory, podLister, nil
+}
+
+func getTrimmedServiceClients(clientset kubernetes.Interface) (informers.SharedInformerFactory, corelisters.ServiceLister, discoverylisters.EndpointSliceLister, error) {
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	serviceInformer := factory.Core().V1().Services().Informer()
+	err := serviceInformer.SetTransform(func(obj any) (any, error) {
+		// Only the existence of services matters, trim everything else.
+		if service, ok := obj.(*v1.Service); ok {
+			service.ObjectMeta = metav1.ObjectMeta{
+				Name:      service.Name,
+				Namespace: service.Namespace,
+			}
+			service.Spec = v1.ServiceSpec{}
+			service.Status = v1.ServiceStatus{}
+		}
+		return obj, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	endpointSliceInformer := factory.Discovery().V1().EndpointSlices().Informer()
+	err = endpointSliceInformer.SetTransform(func(obj any) (any, error) {
+		// Only the number of endpoints matters, trim everything else.
+		if slice, ok := obj.(*discoveryv1.EndpointSlice); ok {
+			slice.ObjectMeta = metav1.ObjectMeta{
+				Name:      slice.Name,
+				Namespace: slice.Namespace,
+			}
+			slice.Endpoints = make([]discoveryv1.Endpoint, len(slice.Endpoints))
+			slice.Ports = nil
+		}
+		return obj, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	serviceLister := factory.Core().V1().Services().Lister()
+	endpointSliceLister := factory.Discovery().V1().EndpointSlices().Lister()
+	return factory, serviceLister, endpointSliceLister, nil
+}
+
+// NewK8sClient gives a k8sClient with the given dependencies. Pods are only
+// counted when podCounterOptions is not nil, the requests of the pods are only
+// summed when requestCounterOptions is not nil, services and endpoints are
+// only counted when countServices is set. Nodes are bucketed into topology
+// domains by their topologyKey label, if not empty.
+func NewK8sClient(clientset kubernetes.Interface, namespace, target string, nodelabels, topologyKey string, extendedResources []string, podCounterOptions *PodCounterOptions, requestCounterOptions *RequestCounterOptions, countServices bool) (K8sClient, error) {
 	// Start the informer to list and watch nodes.
 	stopCh := make(chan struct{})
 	labelOptions := informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
 		opts.LabelSelector = nodelabels
 	})
-	factory, nodeLister, err := getTrimmedNodeClients(clientset, labelOptions)
+	topologyKeys := wellKnownTopologyKeys
+	if topologyKey != "" && !slices.Contains(topologyKeys, topologyKey) {
+		topologyKeys = append(slices.Clone(topologyKeys), topologyKey)
+	}
+	factory, nodeLister, err := getTrimmedNodeClients(clientset, labelOptions, topologyKeys)
 	if err != nil {
 		return nil, err
 	}
 	factory.Start(stopCh)
 	factory.WaitForCacheSync(stopCh)
 
+	var podLister corelisters.PodLister
+	if podCounterOptions != nil {
+		// Start the informer to list and watch non-terminal pods.
+		podOptions := informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = podCounterOptions.LabelSelector
+			opts.FieldSelector = nonTerminalPodsFieldSelector
+		})
+		var podFactory informers.SharedInformerFactory
+		podFactory, podLister, err = getTrimmedPodClients(clientset, informers.WithNamespace(podCounterOptions.Namespace), podOptions)
+		if err != nil {
+			return nil, err
+		}
+		podFactory.Start(stopCh)
+		podFactory.WaitForCacheSync(stopCh)
+	}
+
+	var requestPodLister corelisters.PodLister
+	if requestCounterOptions != nil {
+		// Start the informer to list and watch scheduled non-terminal pods.
+		var requestFactory informers.SharedInformerFactory
+		requestFactory, requestPodLister, err = getTrimmedRequestPodClients(clientset)
+		if err != nil {
+			return nil, err
+		}
+		requestFactory.Start(stopCh)
+		requestFactory.WaitForCacheSync(stopCh)
+	}
+
+	var serviceLister corelisters.ServiceLister
+	var endpointSliceLister discoverylisters.EndpointSliceLister
+	if countServices {
+		// Start the informers to list and watch services and endpoint slices.
+		var serviceFactory informers.SharedInformerFactory
+		serviceFactory, serviceLister, endpointSliceLister, err = getTrimmedServiceClients(clientset)
+		if err != nil {
+			return nil, err
+		}
+		serviceFactory.Start(stopCh)
+		serviceFactory.WaitForCacheSync(stopCh)
+	}
+
 	scaleTargets, err := getScaleTargets(target, namespace)
 	if err != nil {
 		return nil, err
 	}
 
+	resourceNames := make([]v1.ResourceName, 0, len(extendedResources))
+	for _, name := range extendedResources {
+		resourceNames = append(resourceNames, v1.ResourceName(name))
+	}
+
 	return &k8sClient{
-		scaleTargets: scaleTargets,
-		clientset:    clientset,
-		nodeLister:   nodeLister,
-		stopCh:       stopCh,
+		scaleTargets:        scaleTargets,
+		clientset:           clientset,
+		nodeLister:          nodeLister,
+		podLister:           podLister,
+		requestPodLister:    requestPodLister,
+		requestOptions:      requestCounterOptions,
+		serviceLister:       serviceLister,
+		endpointSliceLister: endpointSliceLister,
+		extendedResources:   resourceNames,
+		stopCh:              stopCh,
+		topologyKey:         topologyKey,
+		topologyKeys:        topologyKeys,
 	}, nil
 }
 
 func getScaleTargets(targets, namespace string) (*scaleTargets, error) {
 	st := &scaleTargets{targets: []target{}, namespace: namespace}
+	// No targets are scaled when the expected replicas are only published
+	if strings.TrimSpace(targets) == "" {
+		return st, nil
+	}
 
+	var totalWeight int64
 	for _, el := range strings.Split(targets, ",") {
 		el := strings.TrimSpace(el)
-		target, err := getTarget(el)
+		workload, weight, weighted := strings.Cut(el, "=")
+		workload, domain, zonal := strings.Cut(workload, "@")
+		target, err := getTarget(workload)
 		if err != nil {
 			return &scaleTargets{}, fmt.Errorf("target format error: %v", targets)
 		}
+		if zonal {
+			if domain == "" {
+				return &scaleTargets{}, fmt.Errorf("target format error: %v: empty topology domain of %s", targets, workload)
+			}
+			target.domain = domain
+		}
+		if len(st.targets) > 0 && zonal != st.zonal {
+			return &scaleTargets{}, fmt.Errorf("target format error: %v: either all or none of the targets should be zonal", targets)
+		}
+		st.zonal = zonal
+		if weighted {
+			if err := target.parseWeight(weight); err != nil {
+				return &scaleTargets{}, fmt.Errorf("target format error: %v: %v", targets, err)
+			}
+			totalWeight += int64(target.weight)
+		}
+		if len(st.targets) > 0 && weighted != st.weighted {
+			return &scaleTargets{}, fmt.Errorf("target format error: %v: either all or none of the targets should be weighted", targets)
+		}
+		st.weighted = weighted
 		st.targets = append(st.targets, target)
 	}
+	if st.weighted && st.zonal {
+		return &scaleTargets{}, fmt.Errorf("target format error: %v: zonal targets cannot be weighted", targets)
+	}
+	if st.weighted && totalWeight == 0 {
+		return &scaleTargets{}, fmt.Errorf("target format error: %v: at least one weight should be greater than 0", targets)
+	}
 	return st, nil
 }
 
@@ -135,24 +370,138 @@ func getTarget(t string) (target, error) {
 	}
 	kind := splits[0]
 	name := splits[1]
-	return target{kind, name}, nil
+	return target{kind: kind, name: name}, nil
+}
+
+// parseWeight parses the weight of a target, optionally followed by its
+// minimum replicas, as weight[:min].
+func (t *target) parseWeight(s string) error {
+	weight, minReplicas, hasMin := strings.Cut(s, ":")
+	w, err := strconv.ParseInt(weight, 10, 32)
+	if err != nil || w < 0 {
+		return fmt.Errorf("invalid weight %q of %s/%s", weight, t.kind, t.name)
+	}
+	t.weight = int32(w)
+	if hasMin {
+		m, err := strconv.ParseInt(minReplicas, 10, 32)
+		if err != nil || m < 0 {
+			return fmt.Errorf("invalid min replicas %q of %s/%s", minReplicas, t.kind, t.name)
+		}
+		t.minReplicas = int32(m)
+	}
+	return nil
 }
 
 type target struct {
 	kind string
 	name string
+	// weight is the share of the replicas of a weighted target, which gets at
+	// least minReplicas.
+	weight      int32
+	minReplicas int32
+	// domain is the topology domain of a zonal target, which is scaled from
+	// the nodes of that domain only.
+	domain string
 }
 
 // scaleTargets stores the scalable target resources
 type scaleTargets struct {
 	targets   []target
 	namespace string
+	// weighted splits the replicas across the targets by weight, instead of
+	// scaling each of them to the replicas.
+	weighted bool
+	// zonal scales each target from the nodes of its topology domain.
+	zonal bool
+}
+
+// split returns the replicas of each target. Weighted targets get their share
+// of the replicas rounded down, the replicas left over go one by one to the
+// largest remainders, with ties going to the first targets, and then each
+// target is raised to its minimum replicas.
+func (st *scaleTargets) split(expReplicas int32) []int32 {
+	replicas := make([]int32, len(st.targets))
+	if !st.weighted {
+		for i := range replicas {
+			replicas[i] = expReplicas
+		}
+		return replicas
+	}
+	var totalWeight int64
+	for _, t := range st.targets {
+		totalWeight += int64(t.weight)
+	}
+	leftover := expReplicas
+	remainders := make([]int64, len(st.targets))
+	for i, t := range st.targets {
+		share := int64(expReplicas) * int64(t.weight)
+		replicas[i] = int32(share / totalWeight)
+		remainders[i] = share % totalWeight
+		leftover -= replicas[i]
+	}
+	order := make([]int, len(st.targets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for _, i := range order[:leftover] {
+		replicas[i]++
+	}
+	for i, t := range st.targets {
+		if replicas[i] < t.minReplicas {
+			replicas[i] = t.minReplicas
+		}
+	}
+	return replicas
 }
 
 func (k *k8sClient) GetNamespace() (namespace string) {
 	return k.scaleTargets.namespace
 }
 
+func (k *k8sClient) GetTargets() []string {
+	targets := make([]string, 0, len(k.scaleTargets.targets))
+	for _, t := range k.scaleTargets.targets {
+		targets = append(targets, t.kind+"/"+t.name)
+	}
+	return targets
+}
+
+func (k *k8sClient) ForTarget(workload string) (K8sClient, error) {
+	for _, t := range k.scaleTargets.targets {
+		if strings.EqualFold(t.kind+"/"+t.name, workload) {
+			scoped := *k
+			scoped.scaleTargets = &scaleTargets{targets: []target{t}, namespace: k.scaleTargets.namespace}
+			return &scoped, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a target", workload)
+}
+
+func (k *k8sClient) GetTargetTopologyDomains() map[string]string {
+	if !k.scaleTargets.zonal {
+		return nil
+	}
+	domains := make(map[string]string, len(k.scaleTargets.targets))
+	for _, t := range k.scaleTargets.targets {
+		domains[t.kind+"/"+t.name] = t.domain
+	}
+	return domains
+}
+
+func (k *k8sClient) GetTargetWeights() map[string]int32 {
+	if !k.scaleTargets.weighted {
+		return nil
+	}
+	weights := make(map[string]int32, len(k.scaleTargets.targets))
+	for _, t := range k.scaleTargets.targets {
+		weights[t.kind+"/"+t.name] = t.weight
+	}
+	return weights
+}
+
 func (k *k8sClient) FetchConfigMap(namespace, configmap string) (*v1.ConfigMap, error) {
 	cm, err := k.clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), configmap, metav1.GetOptions{})
 	if err != nil {
@@ -187,12 +536,135 @@ func (k *k8sClient) UpdateConfigMap(namespace, configmap string, params map[stri
 	return cm, nil
 }
 
+func (k *k8sClient) UpdateConfigMapData(configMap *v1.ConfigMap, data map[string]string) (*v1.ConfigMap, error) {
+	updatedConfigMap := configMap.DeepCopy()
+	updatedConfigMap.Data = data
+	cm, err := k.clientset.CoreV1().ConfigMaps(configMap.Namespace).Update(context.TODO(), updatedConfigMap, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	glog.V(0).Infof("Updated ConfigMap %v in namespace %v", configMap.Name, configMap.Namespace)
+	return cm, nil
+}
+
 // ClusterStatus defines the cluster status
 type ClusterStatus struct {
 	TotalNodes       int32
 	SchedulableNodes int32
 	TotalCores       int32
 	SchedulableCores int32
+	// TotalMemory and SchedulableMemory are the allocatable memory in bytes.
+	TotalMemory       int64
+	SchedulableMemory int64
+	// TotalExtendedResources and SchedulableExtendedResources are the allocatable
+	// amounts of the configured extended resources (e.g. nvidia.com/gpu), keyed
+	// by resource name.
+	TotalExtendedResources       map[string]int64
+	SchedulableExtendedResources map[string]int64
+	// TotalPods is the number of non-terminal pods, only counted when the
+	// pod informer is enabled.
+	TotalPods int32
+	// RequestedCores and RequestedMemory are the cpu and memory (in bytes)
+	// requests of the scheduled non-terminal pods, only summed when the
+	// request informer is enabled.
+	RequestedCores  float64
+	RequestedMemory int64
+	// TotalServices and TotalEndpoints are the number of services and of
+	// endpoints across all endpoint slices, only counted when the service
+	// informers are enabled.
+	TotalServices  int32
+	TotalEndpoints int32
+
+	// PodsCounted, RequestsCounted and ServicesCounted tell whether the
+	// informers above are enabled, as the counts stay at 0 otherwise.
+	PodsCounted     bool
+	RequestsCounted bool
+	ServicesCounted bool
+
+	// Signals are additional named scaling inputs, e.g. the number of objects
+	// counted by an ObjectCounter.
+	Signals map[string]float64
+	// TopologyDomains holds the nodes, cores, memory and extended resources of
+	// each topology domain, keyed by the value of the topology label of the
+	// nodes. Nodes without the label are in no domain.
+	TopologyDomains map[string]*ClusterStatus
+	// TopologyNodes holds the number of nodes of each domain of the topology
+	// labels kept on the nodes, keyed by label and then by domain.
+	TopologyNodes map[string]map[string]NodeCounts
+}
+
+// NodeCounts are the numbers of nodes of a topology domain.
+type NodeCounts struct {
+	Total       int32
+	Schedulable int32
+}
+
+// CountTopologyDomains returns the number of domains of the topology label
+// with schedulable nodes, or with any nodes if includeUnschedulableNodes is
+// set, and whether the label is kept on the nodes.
+func (s *ClusterStatus) CountTopologyDomains(key string, includeUnschedulableNodes bool) (int32, bool) {
+	domains, ok := s.TopologyNodes[key]
+	if !ok {
+		return 0, false
+	}
+	var count int32
+	for _, nodes := range domains {
+		if nodes.Schedulable > 0 || (includeUnschedulableNodes && nodes.Total > 0) {
+			count++
+		}
+	}
+	return count, true
+}
+
+// ForTopologyDomain returns the cluster status as seen from a topology domain,
+// with the nodes, cores, memory and extended resources of that domain only.
+// Domains are matched regardless of case, and a domain without nodes has none.
+func (s *ClusterStatus) ForTopologyDomain(domain string) *ClusterStatus {
+	// A domain without nodes still has the topology labels, with no domains.
+	d := &ClusterStatus{TopologyNodes: make(map[string]map[string]NodeCounts, len(s.TopologyNodes))}
+	for key := range s.TopologyNodes {
+		d.TopologyNodes[key] = map[string]NodeCounts{}
+	}
+	for name, status := range s.TopologyDomains {
+		if strings.EqualFold(name, domain) {
+			d = status
+			break
+		}
+	}
+	status := *s
+	status.TotalNodes = d.TotalNodes
+	status.SchedulableNodes = d.SchedulableNodes
+	status.TotalCores = d.TotalCores
+	status.SchedulableCores = d.SchedulableCores
+	status.TotalMemory = d.TotalMemory
+	status.SchedulableMemory = d.SchedulableMemory
+	status.TotalExtendedResources = d.TotalExtendedResources
+	status.SchedulableExtendedResources = d.SchedulableExtendedResources
+	status.TopologyDomains = nil
+	status.TopologyNodes = d.TopologyNodes
+	return &status
+}
+
+// LookupInput returns the amount of the extended resource or of the signal
+// with the given name.
+func (s *ClusterStatus) LookupInput(name string, includeUnschedulableNodes bool) (float64, bool) {
+	resources := s.SchedulableExtendedResources
+	if includeUnschedulableNodes {
+		resources = s.TotalExtendedResources
+	}
+	if amount, ok := resources[name]; ok {
+		return float64(amount), true
+	}
+	value, ok := s.Signals[name]
+	return value, ok
+}
+
+// nonTerminalPodsFieldSelector filters out pods which have run to completion.
+const nonTerminalPodsFieldSelector = "status.phase!=" + string(v1.PodSucceeded) + ",status.phase!=" + string(v1.PodFailed)
+
+// isPodTerminal checks if a pod has run to completion.
+func isPodTerminal(pod *v1.Pod) bool {
+	return pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
 }
 
 // isNodeReady checks if a node is in the "Ready" state.
@@ -212,26 +684,141 @@ func (k *k8sClient) GetClusterStatus() (clusterStatus *ClusterStatus, err error)
 	}
 
 	clusterStatus = &ClusterStatus{}
-	clusterStatus.TotalNodes = int32(len(nodes))
-	var tc resource.Quantity
-	var sc resource.Quantity
+	cluster := newNodeCapacity(k.extendedResources, k.topologyKeys)
+	domains := make(map[string]*nodeCapacity)
 	for _, node := range nodes {
-		tc.Add(node.Status.Allocatable[v1.ResourceCPU])
-		if !node.Spec.Unschedulable && isNodeReady(node) {
-			clusterStatus.SchedulableNodes++
-			sc.Add(node.Status.Allocatable[v1.ResourceCPU])
+		schedulable := !node.Spec.Unschedulable && isNodeReady(node)
+		cluster.add(node, schedulable)
+		if domain, ok := node.Labels[k.topologyKey]; ok && k.topologyKey != "" {
+			if domains[domain] == nil {
+				domains[domain] = newNodeCapacity(k.extendedResources, k.topologyKeys)
+			}
+			domains[domain].add(node, schedulable)
+		}
+	}
+	cluster.setOn(clusterStatus)
+	if k.topologyKey != "" {
+		clusterStatus.TopologyDomains = make(map[string]*ClusterStatus, len(domains))
+		for domain, capacity := range domains {
+			clusterStatus.TopologyDomains[domain] = &ClusterStatus{}
+			capacity.setOn(clusterStatus.TopologyDomains[domain])
 		}
 	}
 
-	clusterStatus.TotalCores = int32(tc.Value())
-	clusterStatus.SchedulableCores = int32(sc.Value())
+	if k.podLister != nil {
+		clusterStatus.PodsCounted = true
+		pods, err := k.podLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			if !isPodTerminal(pod) {
+				clusterStatus.TotalPods++
+			}
+		}
+	}
+
+	if k.requestPodLister != nil {
+		clusterStatus.RequestsCounted = true
+		pods, err := k.requestPodLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		cpu, memory := sumRequests(pods, k.requestOptions)
+		clusterStatus.RequestedCores = float64(cpu.MilliValue()) / 1000
+		clusterStatus.RequestedMemory = memory.Value()
+	}
+
+	if k.serviceLister != nil {
+		clusterStatus.ServicesCounted = true
+		services, err := k.serviceLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		clusterStatus.TotalServices = int32(len(services))
+		endpointSlices, err := k.endpointSliceLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, slice := range endpointSlices {
+			clusterStatus.TotalEndpoints += int32(len(slice.Endpoints))
+		}
+	}
 	k.clusterStatus = clusterStatus
 	return clusterStatus, nil
 }
 
+// nodeCapacity sums the allocatable resources of nodes.
+type nodeCapacity struct {
+	totalNodes, schedulableNodes int32
+	tc, sc, tm, sm               resource.Quantity
+	extendedResources            []v1.ResourceName
+	te, se                       []resource.Quantity
+	topologyNodes                map[string]map[string]NodeCounts
+}
+
+func newNodeCapacity(extendedResources []v1.ResourceName, topologyKeys []string) *nodeCapacity {
+	topologyNodes := make(map[string]map[string]NodeCounts, len(topologyKeys))
+	for _, key := range topologyKeys {
+		topologyNodes[key] = make(map[string]NodeCounts)
+	}
+	return &nodeCapacity{
+		extendedResources: extendedResources,
+		te:                make([]resource.Quantity, len(extendedResources)),
+		se:                make([]resource.Quantity, len(extendedResources)),
+		topologyNodes:     topologyNodes,
+	}
+}
+
+func (c *nodeCapacity) add(node *v1.Node, schedulable bool) {
+	c.totalNodes++
+	c.tc.Add(node.Status.Allocatable[v1.ResourceCPU])
+	c.tm.Add(node.Status.Allocatable[v1.ResourceMemory])
+	if schedulable {
+		c.schedulableNodes++
+		c.sc.Add(node.Status.Allocatable[v1.ResourceCPU])
+		c.sm.Add(node.Status.Allocatable[v1.ResourceMemory])
+	}
+	for i, name := range c.extendedResources {
+		c.te[i].Add(node.Status.Allocatable[name])
+		if schedulable {
+			c.se[i].Add(node.Status.Allocatable[name])
+		}
+	}
+	for key, domains := range c.topologyNodes {
+		domain, ok := node.Labels[key]
+		if !ok {
+			continue
+		}
+		nodes := domains[domain]
+		nodes.Total++
+		if schedulable {
+			nodes.Schedulable++
+		}
+		domains[domain] = nodes
+	}
+}
+
+func (c *nodeCapacity) setOn(status *ClusterStatus) {
+	status.TotalNodes = c.totalNodes
+	status.SchedulableNodes = c.schedulableNodes
+	status.TotalCores = int32(c.tc.Value())
+	status.SchedulableCores = int32(c.sc.Value())
+	status.TotalMemory = c.tm.Value()
+	status.SchedulableMemory = c.sm.Value()
+	status.TotalExtendedResources = make(map[string]int64, len(c.extendedResources))
+	status.SchedulableExtendedResources = make(map[string]int64, len(c.extendedResources))
+	for i, name := range c.extendedResources {
+		status.TotalExtendedResources[string(name)] = c.te[i].Value()
+		status.SchedulableExtendedResources[string(name)] = c.se[i].Value()
+	}
+	status.TopologyNodes = c.topologyNodes
+}
+
 func (k *k8sClient) UpdateReplicas(expReplicas int32) (err error) {
-	for _, target := range k.scaleTargets.targets {
-		_, err := k.UpdateTargetReplicas(expReplicas, target)
+	replicas := k.scaleTargets.split(expReplicas)
+	for i, target := range k.scaleTargets.targets {
+		_, err := k.UpdateTargetReplicas(replicas[i], target)
 		if err != nil {
 			return err
 		}
@@ -254,11 +841,13 @@ func (k *k8sClient) UpdateTargetReplicas(expReplicas int32, target target) (prev
 	prevReplicas = scale.Spec.Replicas
 	if expReplicas != prevReplicas {
 		glog.V(0).Infof(
-			"Cluster status: SchedulableNodes[%v], TotalNodes[%v], SchedulableCores[%v], TotalCores[%v]",
+			"Cluster status: SchedulableNodes[%v], TotalNodes[%v], SchedulableCores[%v], TotalCores[%v], SchedulableMemory[%v], TotalMemory[%v]",
 			k.clusterStatus.SchedulableNodes,
 			k.clusterStatus.TotalNodes,
 			k.clusterStatus.SchedulableCores,
-			k.clusterStatus.TotalCores)
+			k.clusterStatus.TotalCores,
+			k.clusterStatus.SchedulableMemory,
+			k.clusterStatus.TotalMemory)
 		glog.V(0).Infof("Replicas are not as expected : updating %s/%s from %d to %d",
 			target.kind,
 			target.name,
@@ -310,11 +899,13 @@ func (k *k8sClient) updateReplicasAppsV1(expReplicas int32, target target) (prev
 	prevReplicas = scale.Spec.Replicas
 	if expReplicas != prevReplicas {
 		glog.V(0).Infof(
-			"Cluster status: SchedulableNodes[%v], TotalNodes[%v], SchedulableCores[%v], TotalCores[%v]",
+			"Cluster status: SchedulableNodes[%v], TotalNodes[%v], SchedulableCores[%v], TotalCores[%v], SchedulableMemory[%v], TotalMemory[%v]",
 			k.clusterStatus.SchedulableNodes,
 			k.clusterStatus.TotalNodes,
 			k.clusterStatus.SchedulableCores,
-			k.clusterStatus.TotalCores)
+			k.clusterStatus.TotalCores,
+			k.clusterStatus.SchedulableMemory,
+			k.clusterStatus.TotalMemory)
 		glog.V(0).Infof("Replicas are not as expected : updating %s/%s from %d to %d",
 			target.kind,
 			target.name,
@@ -333,6 +924,117 @@ func (k *k8sClient) updateReplicasAppsV1(expReplicas int32, target target) (prev
 	return prevReplicas, nil
 }
 
+func (k *k8sClient) GetReplicas(workload string, ready bool) (int32, error) {
+	target, err := getTarget(workload)
+	if err != nil {
+		return 0, err
+	}
+	if ready {
+		w, err := k.getWorkload(&target)
+		if err != nil {
+			return 0, err
+		}
+		return w.readyReplicas, nil
+	}
+	req, err := requestForTarget(k.clientset.AppsV1().RESTClient().Get(), &target, k.scaleTargets.namespace)
+	if err != nil {
+		return 0, err
+	}
+	scale := &autoscalingv1.Scale{}
+	if err = req.Do(context.TODO()).Into(scale); err != nil {
+		return 0, err
+	}
+	return scale.Spec.Replicas, nil
+}
+
+// workload holds the fields read from a workload object that the scale
+// subresource does not expose.
+type workload struct {
+	readyReplicas int32
+	template      *v1.PodTemplateSpec
+	selector      *metav1.LabelSelector
+}
+
+// getWorkload reads a workload object by its kind.
+func (k *k8sClient) getWorkload(target *target) (*workload, error) {
+	namespace := k.scaleTargets.namespace
+	opt := metav1.GetOptions{}
+	switch strings.ToLower(target.kind) {
+	case "deployment", "deployments":
+		deployment, err := k.clientset.AppsV1().Deployments(namespace).Get(context.TODO(), target.name, opt)
+		if err != nil {
+			return nil, err
+		}
+		return &workload{deployment.Status.ReadyReplicas, &deployment.Spec.Template, deployment.Spec.Selector}, nil
+	case "replicaset", "replicasets":
+		replicaSet, err := k.clientset.AppsV1().ReplicaSets(namespace).Get(context.TODO(), target.name, opt)
+		if err != nil {
+			return nil, err
+		}
+		return &workload{replicaSet.Status.ReadyReplicas, &replicaSet.Spec.Template, replicaSet.Spec.Selector}, nil
+	case "statefulset", "statefulsets":
+		statefulSet, err := k.clientset.AppsV1().StatefulSets(namespace).Get(context.TODO(), target.name, opt)
+		if err != nil {
+			return nil, err
+		}
+		return &workload{statefulSet.Status.ReadyReplicas, &statefulSet.Spec.Template, statefulSet.Spec.Selector}, nil
+	case "replicationcontroller", "replicationcontrollers":
+		rc, err := k.clientset.CoreV1().ReplicationControllers(namespace).Get(context.TODO(), target.name, opt)
+		if err != nil {
+			return nil, err
+		}
+		return &workload{rc.Status.ReadyReplicas, rc.Spec.Template, &metav1.LabelSelector{MatchLabels: rc.Spec.Selector}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported target kind: %v", target.kind)
+	}
+}
+
+func (k *k8sClient) GetTargetPodRequests() (v1.ResourceList, error) {
+	if len(k.scaleTargets.targets) == 0 {
+		return nil, fmt.Errorf("no target to read the pod template of")
+	}
+	w, err := k.getWorkload(&k.scaleTargets.targets[0])
+	if err != nil {
+		return nil, err
+	}
+	if w.template == nil {
+		return nil, fmt.Errorf("%s %s has no pod template", k.scaleTargets.targets[0].kind, k.scaleTargets.targets[0].name)
+	}
+	return podRequests(&v1.Pod{Spec: w.template.Spec}), nil
+}
+
+func (k *k8sClient) GetTargetRequests() (v1.ResourceList, error) {
+	if k.requestPodLister == nil {
+		return nil, fmt.Errorf("the pod requests are not counted")
+	}
+	seen := make(map[string]bool)
+	var pods []*v1.Pod
+	for i := range k.scaleTargets.targets {
+		target := &k.scaleTargets.targets[i]
+		w, err := k.getWorkload(target)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(w.selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of %s %s: %v", target.kind, target.name, err)
+		}
+		targetPods, err := k.requestPodLister.Pods(k.scaleTargets.namespace).List(selector)
+		if err != nil {
+			return nil, err
+		}
+		// Targets may select the same pods
+		for _, pod := range targetPods {
+			if !seen[pod.Name] {
+				seen[pod.Name] = true
+				pods = append(pods, pod)
+			}
+		}
+	}
+	cpu, memory := sumRequests(pods, k.requestOptions)
+	return v1.ResourceList{v1.ResourceCPU: cpu, v1.ResourceMemory: memory}, nil
+}
+
 func requestForTarget(req *rest.Request, target *target, namespace string) (*rest.Request, error) {
 	var absPath, resource string
 	// Support the kinds we allowed scaling via the extensions API group
@@ -18,9 +18,12 @@ package k8sclient
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -111,6 +114,65 @@ func TestGetScaleTargets(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"",
+			&scaleTargets{
+				targets: []target{},
+			},
+			false,
+		},
+		{
+			"deployment/first=3:1,deployment/second=1",
+			&scaleTargets{
+				targets: []target{
+					{kind: "deployment", name: "first", weight: 3, minReplicas: 1},
+					{kind: "deployment", name: "second", weight: 1},
+				},
+				weighted: true,
+			},
+			false,
+		},
+		{
+			"deployment/first=3,deployment/second",
+			&scaleTargets{},
+			true,
+		},
+		{
+			"deployment/first=0,deployment/second=0",
+			&scaleTargets{},
+			true,
+		},
+		{
+			"deployment/first=three",
+			&scaleTargets{},
+			true,
+		},
+		{
+			"deployment/dns-a@us-east-1a,deployment/dns-b@us-east-1b",
+			&scaleTargets{
+				targets: []target{
+					{kind: "deployment", name: "dns-a", domain: "us-east-1a"},
+					{kind: "deployment", name: "dns-b", domain: "us-east-1b"},
+				},
+				zonal: true,
+			},
+			false,
+		},
+		{
+			"deployment/dns-a@us-east-1a,deployment/dns-b",
+			&scaleTargets{},
+			true,
+		},
+		{
+			"deployment/dns-a@",
+			&scaleTargets{},
+			true,
+		},
+		{
+			"deployment/dns-a@us-east-1a=1,deployment/dns-b@us-east-1b=1",
+			&scaleTargets{},
+			true,
+		},
 		{
 			"deployment/first deployment/second",
 			&scaleTargets{
@@ -136,9 +198,14 @@ func TestGetScaleTargets(t *testing.T) {
 			t.Errorf("Expected targets vs resulted targets should be the same length: %v vs %v", len(tc.expScaleTargets.targets), len(res.targets))
 			continue
 		}
+		if res.weighted != tc.expScaleTargets.weighted && !tc.expError {
+			t.Errorf("Expected weighted %v, got %v for target: %v", tc.expScaleTargets.weighted, res.weighted, tc.target)
+		}
+		if res.zonal != tc.expScaleTargets.zonal && !tc.expError {
+			t.Errorf("Expected zonal %v, got %v for target: %v", tc.expScaleTargets.zonal, res.zonal, tc.target)
+		}
 		for i, resTarget := range res.targets {
-			if resTarget.kind != tc.expScaleTargets.targets[i].kind ||
-				resTarget.name != tc.expScaleTargets.targets[i].name {
+			if resTarget != tc.expScaleTargets.targets[i] {
 				t.Errorf("Expect kind: %v, name: %v\ngot kind: %v, name: %v", tc.expScaleTargets.targets[i].kind,
 					tc.expScaleTargets.targets[i].name, resTarget.kind, resTarget.name)
 			}
@@ -146,6 +213,72 @@ func TestGetScaleTargets(t *testing.T) {
 	}
 }
 
+func TestSplitReplicas(t *testing.T) {
+	testCases := []struct {
+		target      string
+		expReplicas int32
+		expSplit    []int32
+	}{
+		// Unweighted targets all get the replicas
+		{"deployment/first,deployment/second", 5, []int32{5, 5}},
+		{"deployment/dns-amd64=3,deployment/dns-arm64=1", 8, []int32{6, 2}},
+		// The leftover goes to the largest remainder
+		{"deployment/dns-amd64=3,deployment/dns-arm64=1", 7, []int32{5, 2}},
+		{"deployment/dns-amd64=3,deployment/dns-arm64=1", 5, []int32{4, 1}},
+		// Ties go to the first targets
+		{"deployment/a=1,deployment/b=1,deployment/c=1", 4, []int32{2, 1, 1}},
+		{"deployment/a=1,deployment/b=1,deployment/c=1", 5, []int32{2, 2, 1}},
+		// Minimums are applied after the split
+		{"deployment/stable=9,deployment/canary=1:1", 5, []int32{5, 1}},
+		{"deployment/stable=9:2,deployment/canary=1:1", 0, []int32{2, 1}},
+		{"deployment/stable=1,deployment/canary=0:1", 3, []int32{3, 1}},
+	}
+
+	for _, tc := range testCases {
+		st, err := getScaleTargets(tc.target, "default")
+		if err != nil {
+			t.Fatalf("Unexpected error for target %v: %v", tc.target, err)
+		}
+		if split := st.split(tc.expReplicas); !reflect.DeepEqual(split, tc.expSplit) {
+			t.Errorf("split(%d)=%v, want %v for target %v", tc.expReplicas, split, tc.expSplit, tc.target)
+		}
+	}
+}
+
+func TestForTarget(t *testing.T) {
+	st, err := getScaleTargets("deployment/stable=9:2,Deployment/Canary=1:1", "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	k := &k8sClient{scaleTargets: st}
+	if targets := k.GetTargets(); !reflect.DeepEqual(targets, []string{"deployment/stable", "Deployment/Canary"}) {
+		t.Errorf("GetTargets()=%v, want the targets as kind/name", targets)
+	}
+	client, err := k.ForTarget("deployment/canary")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The scoped target is scaled to the replicas as is
+	scoped := client.(*k8sClient).scaleTargets
+	expected := &scaleTargets{
+		targets:   []target{{kind: "Deployment", name: "Canary", weight: 1, minReplicas: 1}},
+		namespace: "default",
+	}
+	if !reflect.DeepEqual(scoped, expected) {
+		t.Errorf("ForTarget() scaled %v, want %v", scoped, expected)
+	}
+	if split := scoped.split(5); !reflect.DeepEqual(split, []int32{5}) {
+		t.Errorf("split(5)=%v, want [5]", split)
+	}
+	expWeights := map[string]int32{"deployment/stable": 9, "Deployment/Canary": 1}
+	if weights := k.GetTargetWeights(); !reflect.DeepEqual(weights, expWeights) {
+		t.Errorf("GetTargetWeights()=%v, want %v", weights, expWeights)
+	}
+	if _, err := k.ForTarget("deployment/other"); err == nil {
+		t.Errorf("Expect error, got no error for a workload which is not a target")
+	}
+}
+
 func TestNewK8sClient(t *testing.T) {
 	client := fake.NewSimpleClientset()
 
@@ -155,6 +288,9 @@ func TestNewK8sClient(t *testing.T) {
 	q2, _ := resource.ParseQuantity("2000m")
 	q3, _ := resource.ParseQuantity("3000m")
 	q4, _ := resource.ParseQuantity("4000m")
+	m1, _ := resource.ParseQuantity("1Gi")
+	m2, _ := resource.ParseQuantity("2Gi")
+	gpus := resource.MustParse("4")
 
 	readyConditions := []v1.NodeCondition{
 		{Type: v1.NodeReady, Status: v1.ConditionTrue},
@@ -180,7 +316,9 @@ func TestNewK8sClient(t *testing.T) {
 		},
 		Status: v1.NodeStatus{
 			Allocatable: v1.ResourceList{
-				v1.ResourceCPU: q1,
+				v1.ResourceCPU:    q1,
+				v1.ResourceMemory: m1,
+				"nvidia.com/gpu":  gpus,
 			},
 			Phase:      v1.NodeRunning,
 			Conditions: readyConditions,
@@ -203,7 +341,8 @@ func TestNewK8sClient(t *testing.T) {
 		},
 		Status: v1.NodeStatus{
 			Allocatable: v1.ResourceList{
-				v1.ResourceCPU: q2,
+				v1.ResourceCPU:    q2,
+				v1.ResourceMemory: m2,
 			},
 			Phase:      v1.NodeRunning,
 			Conditions: readyConditions,
@@ -227,7 +366,9 @@ func TestNewK8sClient(t *testing.T) {
 		},
 		Status: v1.NodeStatus{
 			Allocatable: v1.ResourceList{
-				v1.ResourceCPU: q3,
+				v1.ResourceCPU:    q3,
+				v1.ResourceMemory: m2,
+				"nvidia.com/gpu":  gpus,
 			},
 			Phase: v1.NodeRunning,
 		},
@@ -250,7 +391,8 @@ func TestNewK8sClient(t *testing.T) {
 		},
 		Status: v1.NodeStatus{
 			Allocatable: v1.ResourceList{
-				v1.ResourceCPU: q4,
+				v1.ResourceCPU:    q4,
+				v1.ResourceMemory: m2,
 			},
 			Phase: v1.NodeRunning,
 		},
@@ -273,7 +415,8 @@ func TestNewK8sClient(t *testing.T) {
 		},
 		Status: v1.NodeStatus{
 			Allocatable: v1.ResourceList{
-				v1.ResourceCPU: q3,
+				v1.ResourceCPU:    q3,
+				v1.ResourceMemory: m2,
 			},
 			Phase:      v1.NodeRunning,
 			Conditions: notReadyConditions,
@@ -287,7 +430,7 @@ func TestNewK8sClient(t *testing.T) {
 		}
 	}
 
-	k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/test-target", nodeLabels)
+	k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/test-target", nodeLabels, "", []string{"nvidia.com/gpu"}, nil, nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -307,6 +450,458 @@ func TestNewK8sClient(t *testing.T) {
 	if status.SchedulableCores != 3 {
 		t.Errorf("status.SchedulableCore=%v, want 3", status.SchedulableCores)
 	}
+	if want := int64(7 << 30); status.TotalMemory != want {
+		t.Errorf("status.TotalMemory=%v, want %v", status.TotalMemory, want)
+	}
+	if want := int64(3 << 30); status.SchedulableMemory != want {
+		t.Errorf("status.SchedulableMemory=%v, want %v", status.SchedulableMemory, want)
+	}
+	if gpus := status.TotalExtendedResources["nvidia.com/gpu"]; gpus != 8 {
+		t.Errorf("status.TotalExtendedResources[nvidia.com/gpu]=%v, want 8", gpus)
+	}
+	if gpus := status.SchedulableExtendedResources["nvidia.com/gpu"]; gpus != 4 {
+		t.Errorf("status.SchedulableExtendedResources[nvidia.com/gpu]=%v, want 4", gpus)
+	}
+}
+
+func TestCountPods(t *testing.T) {
+	newPod := func(name, namespace, app string, phase v1.PodPhase) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app": app,
+				},
+			},
+			Spec: v1.PodSpec{
+				NodeName: "test-node-1",
+			},
+			Status: v1.PodStatus{
+				Phase: phase,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		podCounterOptions *PodCounterOptions
+		expPods           int32
+	}{
+		{
+			name:              "disabled",
+			podCounterOptions: nil,
+			expPods:           0,
+		},
+		{
+			name:              "all namespaces",
+			podCounterOptions: &PodCounterOptions{},
+			expPods:           4,
+		},
+		{
+			name:              "single namespace",
+			podCounterOptions: &PodCounterOptions{Namespace: "kube-system"},
+			expPods:           2,
+		},
+		{
+			name:              "label selector",
+			podCounterOptions: &PodCounterOptions{LabelSelector: "app=dns"},
+			expPods:           3,
+		},
+		{
+			name:              "namespace and label selector",
+			podCounterOptions: &PodCounterOptions{Namespace: "default", LabelSelector: "app=dns"},
+			expPods:           1,
+		},
+	}
+
+	for _, tc := range testCases {
+		client := fake.NewSimpleClientset()
+		for _, pod := range []*v1.Pod{
+			newPod("pending", "kube-system", "dns", v1.PodPending),
+			newPod("running", "kube-system", "dns", v1.PodRunning),
+			newPod("succeeded", "kube-system", "dns", v1.PodSucceeded),
+			newPod("failed", "default", "dns", v1.PodFailed),
+			newPod("unknown", "default", "dns", v1.PodUnknown),
+			newPod("other", "default", "web", v1.PodRunning),
+		} {
+			if _, err := client.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/test-target", "", "", nil, tc.podCounterOptions, nil, false)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		status, err := k8sClient.GetClusterStatus()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if status.TotalPods != tc.expPods {
+			t.Errorf("%s: status.TotalPods=%v, want %v", tc.name, status.TotalPods, tc.expPods)
+		}
+	}
+}
+
+func TestCountServices(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	for _, service := range []*v1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+	} {
+		if _, err := client.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, slice := range []*discoveryv1.EndpointSlice{
+		{
+			ObjectMeta:  metav1.ObjectMeta{Name: "dns-1", Namespace: "kube-system"},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}},
+				{Addresses: []string{"10.0.0.2"}},
+			},
+		},
+		{
+			ObjectMeta:  metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.1.1"}},
+				{Addresses: []string{"10.0.1.2"}},
+				{Addresses: []string{"10.0.1.3"}},
+			},
+		},
+		{
+			ObjectMeta:  metav1.ObjectMeta{Name: "db-1", Namespace: "default"},
+			AddressType: discoveryv1.AddressTypeIPv4,
+		},
+	} {
+		if _, err := client.DiscoveryV1().EndpointSlices(slice.Namespace).Create(context.Background(), slice, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		countServices bool
+		expServices   int32
+		expEndpoints  int32
+	}{
+		{false, 0, 0},
+		{true, 3, 5},
+	}
+
+	for _, tc := range testCases {
+		k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/test-target", "", "", nil, nil, nil, tc.countServices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		status, err := k8sClient.GetClusterStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.TotalServices != tc.expServices {
+			t.Errorf("status.TotalServices=%v, want %v", status.TotalServices, tc.expServices)
+		}
+		if status.TotalEndpoints != tc.expEndpoints {
+			t.Errorf("status.TotalEndpoints=%v, want %v", status.TotalEndpoints, tc.expEndpoints)
+		}
+	}
+}
+
+func TestUpdateConfigMapData(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shards",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "shards"},
+			Annotations: map[string]string{"owner": "app"},
+		},
+		Data: map[string]string{"value": "4"},
+	})
+	k := &k8sClient{clientset: client}
+	configMap, err := k.FetchConfigMap("default", "shards")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.UpdateConfigMapData(configMap, map[string]string{"value": "5", "shards.yaml": "owner: app"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if configMap.Data["value"] != "4" {
+		t.Errorf("Expected the fetched ConfigMap to be left as is, got %v", configMap.Data)
+	}
+	updated, err := k.FetchConfigMap("default", "shards")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(updated.Data, map[string]string{"value": "5", "shards.yaml": "owner: app"}) {
+		t.Errorf("Unexpected data %v", updated.Data)
+	}
+	if updated.Labels["app"] != "shards" || updated.Annotations["owner"] != "app" {
+		t.Errorf("Expected the metadata to be kept, got %v", updated.ObjectMeta)
+	}
+}
+
+func TestGetReadyReplicas(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "test-namespace"},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 8},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test-namespace"},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 3},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 5},
+		},
+	)
+	k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/test-target", "", "", nil, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		workload    string
+		expError    bool
+		expReplicas int32
+	}{
+		{"deployment/frontend", false, 8},
+		{"StatefulSet/db", false, 3},
+		// Workloads are read from the namespace of the targets
+		{"deployment/backend", true, 0},
+		{"daemonset/frontend", true, 0},
+		{"frontend", true, 0},
+	}
+
+	for _, tc := range testCases {
+		replicas, err := k8sClient.GetReplicas(tc.workload, true)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for workload %s", tc.workload)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for workload %s: %v", tc.workload, err)
+			continue
+		}
+		if replicas != tc.expReplicas {
+			t.Errorf("GetReplicas(%s, true)=%v, want %v", tc.workload, replicas, tc.expReplicas)
+		}
+	}
+}
+
+func TestGetTargetPodRequests(t *testing.T) {
+	template := v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("250m"),
+					v1.ResourceMemory: resource.MustParse("256Mi"),
+				}}},
+				{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU: resource.MustParse("250m"),
+				}}},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace"},
+			Spec:       appsv1.DeploymentSpec{Template: template},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test-namespace"},
+			Spec:       appsv1.StatefulSetSpec{Template: template},
+		},
+	)
+
+	testCases := []struct {
+		target    string
+		expError  bool
+		expCPU    string
+		expMemory string
+	}{
+		{"deployment/web", false, "500m", "256Mi"},
+		// Only the first target is read
+		{"statefulset/db,deployment/other", false, "500m", "256Mi"},
+		{"deployment/other", true, "", ""},
+	}
+
+	for _, tc := range testCases {
+		k8sClient, err := NewK8sClient(client, "test-namespace", tc.target, "", "", nil, nil, nil, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requests, err := k8sClient.GetTargetPodRequests()
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for target %s", tc.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for target %s: %v", tc.target, err)
+			continue
+		}
+		if cpu := requests[v1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expCPU)) != 0 {
+			t.Errorf("cpu=%v, want %v for target %s", cpu.String(), tc.expCPU, tc.target)
+		}
+		if memory := requests[v1.ResourceMemory]; memory.Cmp(resource.MustParse(tc.expMemory)) != 0 {
+			t.Errorf("memory=%v, want %v for target %s", memory.String(), tc.expMemory, tc.target)
+		}
+	}
+}
+
+func TestGetTrimmedPodClients(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	testPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod-1",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"eating-memory": "a-lot",
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName: "test-node-1",
+		},
+		Status: v1.PodStatus{
+			Phase:  v1.PodRunning,
+			PodIP:  "10.0.1.1",
+			HostIP: "10.0.0.1",
+		},
+	}
+	_, err := client.CoreV1().Pods(testPod.Namespace).Create(context.Background(), testPod, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Start the informer.
+	factory, podLister, err := getTrimmedPodClients(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	pods, err := podLister.List(labels.NewSelector())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 1 {
+		t.Fatalf("len(pods)=%v, want 1", len(pods))
+	}
+	pod := pods[0]
+	if pod.Annotations != nil {
+		t.Errorf("pod.ObjectMeta is not trimmed. Got %+v", pod.ObjectMeta)
+	}
+	if pod.Spec.NodeName != "" {
+		t.Errorf("pod.Spec is not trimmed. Got %+v", pod.Spec)
+	}
+	if pod.Status.PodIP != "" {
+		t.Errorf("pod.Status is not trimmed. Got %+v", pod.Status)
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		t.Errorf("pod.Status.Phase=%v, want %v", pod.Status.Phase, v1.PodRunning)
+	}
+}
+
+func TestGetClusterStatus_TopologyDomains(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	readyConditions := []v1.NodeCondition{
+		{Type: v1.NodeReady, Status: v1.ConditionTrue},
+	}
+	newNode := func(name, zone, cpu string, unschedulable bool) *v1.Node {
+		node := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.NodeSpec{Unschedulable: unschedulable},
+			Status: v1.NodeStatus{
+				Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+				Conditions:  readyConditions,
+			},
+		}
+		if zone != "" {
+			node.Labels = map[string]string{"topology.kubernetes.io/zone": zone}
+		}
+		return node
+	}
+	for _, node := range []*v1.Node{
+		newNode("node-a1", "us-east-1a", "1", false),
+		newNode("node-a2", "us-east-1a", "2", true),
+		newNode("node-b1", "us-east-1b", "4", false),
+		// Nodes without the topology label are in no domain
+		newNode("node-other", "", "8", false),
+	} {
+		if _, err := client.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/dns-a@us-east-1a,deployment/dns-b@us-east-1b", "", "topology.kubernetes.io/zone", nil, nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := k8sClient.GetClusterStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.TotalNodes != 4 || status.TotalCores != 15 {
+		t.Errorf("status.TotalNodes=%v, status.TotalCores=%v, want 4 and 15", status.TotalNodes, status.TotalCores)
+	}
+	if len(status.TopologyDomains) != 2 {
+		t.Fatalf("len(status.TopologyDomains)=%v, want 2", len(status.TopologyDomains))
+	}
+
+	testCases := []struct {
+		domain         string
+		expTotal       int32
+		expSchedulable int32
+		expTotalCores  int32
+	}{
+		{"us-east-1a", 2, 1, 3},
+		// Domains are matched regardless of case
+		{"US-EAST-1B", 1, 1, 4},
+		{"us-east-1c", 0, 0, 0},
+	}
+	for _, tc := range testCases {
+		domainStatus := status.ForTopologyDomain(tc.domain)
+		if domainStatus.TotalNodes != tc.expTotal || domainStatus.SchedulableNodes != tc.expSchedulable || domainStatus.TotalCores != tc.expTotalCores {
+			t.Errorf("ForTopologyDomain(%s) nodes %d, schedulable nodes %d, cores %d, want %d, %d and %d", tc.domain,
+				domainStatus.TotalNodes, domainStatus.SchedulableNodes, domainStatus.TotalCores, tc.expTotal, tc.expSchedulable, tc.expTotalCores)
+		}
+	}
+
+	topologyTestCases := []struct {
+		status                    *ClusterStatus
+		key                       string
+		includeUnschedulableNodes bool
+		expCount                  int32
+		expKnown                  bool
+	}{
+		{status, "topology.kubernetes.io/zone", false, 2, true},
+		{status, "kubernetes.io/hostname", false, 0, true},
+		// Only the well-known and configured topology labels are kept
+		{status, "example.com/rack", false, 0, false},
+		{status.ForTopologyDomain("us-east-1a"), "topology.kubernetes.io/zone", false, 1, true},
+		{status.ForTopologyDomain("us-east-1c"), "topology.kubernetes.io/zone", false, 0, true},
+	}
+	for _, tc := range topologyTestCases {
+		count, known := tc.status.CountTopologyDomains(tc.key, tc.includeUnschedulableNodes)
+		if count != tc.expCount || known != tc.expKnown {
+			t.Errorf("CountTopologyDomains(%s)=%d, %v, want %d, %v", tc.key, count, known, tc.expCount, tc.expKnown)
+		}
+	}
+
+	expDomains := map[string]string{"deployment/dns-a": "us-east-1a", "deployment/dns-b": "us-east-1b"}
+	if domains := k8sClient.GetTargetTopologyDomains(); !reflect.DeepEqual(domains, expDomains) {
+		t.Errorf("GetTargetTopologyDomains()=%v, want %v", domains, expDomains)
+	}
 }
 
 func TestGetTrimmedNodeClients(t *testing.T) {
@@ -314,11 +909,13 @@ func TestGetTrimmedNodeClients(t *testing.T) {
 
 	// Create the test node beforehand.
 	q1, _ := resource.ParseQuantity("1000m")
+	m1, _ := resource.ParseQuantity("1Gi")
 	testNode1 := &v1.Node{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "test-node-1",
 			Labels: map[string]string{
-				"app": "autoscaler",
+				"app":                         "autoscaler",
+				"topology.kubernetes.io/zone": "us-east-1a",
 			},
 			Annotations: map[string]string{
 				"eating-memory": "a-lot",
@@ -331,7 +928,8 @@ func TestGetTrimmedNodeClients(t *testing.T) {
 		},
 		Status: v1.NodeStatus{
 			Allocatable: v1.ResourceList{
-				v1.ResourceCPU: q1,
+				v1.ResourceCPU:    q1,
+				v1.ResourceMemory: m1,
 			},
 			Phase: v1.NodeRunning,
 		},
@@ -343,7 +941,7 @@ func TestGetTrimmedNodeClients(t *testing.T) {
 
 	// Start the informer.
 	labelOptions := informers.WithTweakListOptions(func(opts *metav1.ListOptions) {})
-	factory, nodelister, err := getTrimmedNodeClients(client, labelOptions)
+	factory, nodelister, err := getTrimmedNodeClients(client, labelOptions, []string{"topology.kubernetes.io/zone"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -363,10 +961,17 @@ func TestGetTrimmedNodeClients(t *testing.T) {
 	if node.Annotations != nil {
 		t.Errorf("node.ObjectMeta is not trimmed. Got %+v", node.ObjectMeta)
 	}
+	// Only the topology label is kept
+	if !reflect.DeepEqual(node.Labels, map[string]string{"topology.kubernetes.io/zone": "us-east-1a"}) {
+		t.Errorf("node.Labels=%v, want only the topology label", node.Labels)
+	}
 	if node.Spec.PodCIDR != "" {
 		t.Errorf("node.Spec is not trimmed. Got %+v", node.Spec)
 	}
 	if node.Status.Phase != "" {
 		t.Errorf("node.Status is not trimmed. Got %+v", node.Status)
 	}
+	if memory := node.Status.Allocatable[v1.ResourceMemory]; memory.Cmp(m1) != 0 {
+		t.Errorf("node.Status.Allocatable memory=%v, want %v", memory.String(), m1.String())
+	}
 }
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	customclient "k8s.io/metrics/pkg/client/custom_metrics"
+	externalclient "k8s.io/metrics/pkg/client/external_metrics"
+)
+
+// CustomMetricSpec selects a metric describing a single object, served by the
+// custom.metrics.k8s.io API.
+type CustomMetricSpec struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Namespace of the described object, which is cluster-scoped if empty.
+	Namespace string `json:"namespace"`
+	Metric    string `json:"metric"`
+	// Selector further selects the series of the metric by their labels.
+	Selector string `json:"selector"`
+}
+
+// ExternalMetricSpec selects a metric served by the external.metrics.k8s.io API.
+type ExternalMetricSpec struct {
+	// Namespace of the metric, the namespace of the target if empty.
+	Namespace string `json:"namespace"`
+	Metric    string `json:"metric"`
+	// Selector further selects the series of the metric by their labels.
+	Selector string `json:"selector"`
+}
+
+// MetricsClient reads metrics from the custom and external metrics APIs.
+type MetricsClient interface {
+	// GetCustomMetric returns the value of a metric describing an object.
+	GetCustomMetric(spec CustomMetricSpec) (float64, error)
+	// GetExternalMetric returns the sum of the values of all the series of an
+	// external metric matching the selector.
+	GetExternalMetric(spec ExternalMetricSpec) (float64, error)
+}
+
+type metricsClient struct {
+	customClient   customclient.CustomMetricsClient
+	externalClient externalclient.ExternalMetricsClient
+	namespace      string
+}
+
+// NewMetricsClient gives a MetricsClient reading metrics through the aggregated
+// metrics APIs, defaulting the namespace of external metrics to namespace.
+func NewMetricsClient(config *rest.Config, discoveryClient discovery.DiscoveryInterface, namespace string) (MetricsClient, error) {
+	// The metrics APIs are only served as JSON.
+	config = rest.CopyConfig(config)
+	config.ContentType = runtime.ContentTypeJSON
+	// The metrics APIs and the kinds of described objects are discovered lazily.
+	cachedDiscovery := memory.NewMemCacheClient(discoveryClient)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery)
+	customClient := customclient.NewForConfig(config, mapper, customclient.NewAvailableAPIsGetter(discoveryClient))
+	externalClient, err := externalclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsClient{
+		customClient:   customClient,
+		externalClient: externalClient,
+		namespace:      namespace,
+	}, nil
+}
+
+func (m *metricsClient) GetCustomMetric(spec CustomMetricSpec) (float64, error) {
+	if spec.Kind == "" || spec.Name == "" || spec.Metric == "" {
+		return 0, fmt.Errorf("kind, name and metric should be provided, got: %+v", spec)
+	}
+	gv, err := schema.ParseGroupVersion(spec.APIVersion)
+	if err != nil {
+		return 0, fmt.Errorf("invalid apiVersion %q: %v", spec.APIVersion, err)
+	}
+	selector, err := labels.Parse(spec.Selector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector %q: %v", spec.Selector, err)
+	}
+	metrics := m.customClient.RootScopedMetrics()
+	if spec.Namespace != "" {
+		metrics = m.customClient.NamespacedMetrics(spec.Namespace)
+	}
+	value, err := metrics.GetForObject(schema.GroupKind{Group: gv.Group, Kind: spec.Kind}, spec.Name, spec.Metric, selector)
+	if err != nil {
+		return 0, err
+	}
+	return value.Value.AsApproximateFloat64(), nil
+}
+
+func (m *metricsClient) GetExternalMetric(spec ExternalMetricSpec) (float64, error) {
+	if spec.Metric == "" {
+		return 0, fmt.Errorf("metric should be provided, got: %+v", spec)
+	}
+	selector, err := labels.Parse(spec.Selector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector %q: %v", spec.Selector, err)
+	}
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = m.namespace
+	}
+	values, err := m.externalClient.NamespacedMetrics(namespace).List(spec.Metric, selector)
+	if err != nil {
+		return 0, err
+	}
+	if len(values.Items) == 0 {
+		return 0, fmt.Errorf("no series of external metric %q matching %q", spec.Metric, spec.Selector)
+	}
+	var sum float64
+	for _, value := range values.Items {
+		sum += value.Value.AsApproximateFloat64()
+	}
+	return sum, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	custommetricsv1beta2 "k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	externalmetricsv1beta1 "k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+	customfake "k8s.io/metrics/pkg/client/custom_metrics/fake"
+	externalfake "k8s.io/metrics/pkg/client/external_metrics/fake"
+)
+
+func TestGetCustomMetric(t *testing.T) {
+	customClient := &customfake.FakeCustomMetricsClient{}
+	customClient.AddReactor("get", "*", func(action core.Action) (bool, runtime.Object, error) {
+		getForAction := action.(customfake.GetForAction)
+		key := fmt.Sprintf("%s/%s/%s/%s", getForAction.GetResource().Resource, getForAction.GetNamespace(), getForAction.GetName(), getForAction.GetMetricName())
+		values := map[string]string{
+			"ingresses.networking.k8s.io/web/main/requests_per_second": "1500m",
+			"nodes//node-1/network_bytes":                              "2k",
+		}
+		value, ok := values[key]
+		if !ok {
+			return true, nil, fmt.Errorf("no metric %s", key)
+		}
+		return true, &custommetricsv1beta2.MetricValueList{
+			Items: []custommetricsv1beta2.MetricValue{{Value: resource.MustParse(value)}},
+		}, nil
+	})
+	client := &metricsClient{customClient: customClient, namespace: "kube-system"}
+
+	testCases := []struct {
+		spec     CustomMetricSpec
+		expError bool
+		expValue float64
+	}{
+		{
+			CustomMetricSpec{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "main", Namespace: "web", Metric: "requests_per_second"},
+			false,
+			1.5,
+		},
+		{
+			// Objects without namespace are cluster-scoped.
+			CustomMetricSpec{APIVersion: "v1", Kind: "Node", Name: "node-1", Metric: "network_bytes"},
+			false,
+			2000,
+		},
+		{
+			CustomMetricSpec{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "other", Namespace: "web", Metric: "requests_per_second"},
+			true,
+			0,
+		},
+		{
+			CustomMetricSpec{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Namespace: "web", Metric: "requests_per_second"},
+			true,
+			0,
+		},
+		{
+			CustomMetricSpec{APIVersion: "a/b/c", Kind: "Ingress", Name: "main", Namespace: "web", Metric: "requests_per_second"},
+			true,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		value, err := client.GetCustomMetric(tc.spec)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for spec %+v", tc.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for spec %+v: %v", tc.spec, err)
+			continue
+		}
+		if value != tc.expValue {
+			t.Errorf("GetCustomMetric(%+v)=%v, want %v", tc.spec, value, tc.expValue)
+		}
+	}
+}
+
+func TestGetExternalMetric(t *testing.T) {
+	externalClient := &externalfake.FakeExternalMetricsClient{}
+	externalClient.AddReactor("list", "*", func(action core.Action) (bool, runtime.Object, error) {
+		listAction := action.(core.ListAction)
+		list := &externalmetricsv1beta1.ExternalMetricValueList{}
+		if listAction.GetNamespace() != "kube-system" || listAction.GetResource().Resource != "queue_messages_ready" {
+			return true, list, nil
+		}
+		values := []string{"10", "32"}
+		if listAction.GetListRestrictions().Labels.String() == "queue=orders" {
+			values = values[:1]
+		}
+		for _, value := range values {
+			list.Items = append(list.Items, externalmetricsv1beta1.ExternalMetricValue{Value: resource.MustParse(value)})
+		}
+		return true, list, nil
+	})
+	client := &metricsClient{externalClient: externalClient, namespace: "kube-system"}
+
+	testCases := []struct {
+		spec     ExternalMetricSpec
+		expError bool
+		expValue float64
+	}{
+		{
+			ExternalMetricSpec{Metric: "queue_messages_ready"},
+			false,
+			42,
+		},
+		{
+			ExternalMetricSpec{Namespace: "kube-system", Metric: "queue_messages_ready", Selector: "queue=orders"},
+			false,
+			10,
+		},
+		{
+			ExternalMetricSpec{Namespace: "default", Metric: "queue_messages_ready"},
+			true,
+			0,
+		},
+		{
+			ExternalMetricSpec{Metric: "queue_messages_ready", Selector: "queue in (orders"},
+			true,
+			0,
+		},
+		{
+			ExternalMetricSpec{},
+			true,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		value, err := client.GetExternalMetric(tc.spec)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for spec %+v", tc.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for spec %+v: %v", tc.spec, err)
+			continue
+		}
+		if value != tc.expValue {
+			t.Errorf("GetExternalMetric(%+v)=%v, want %v", tc.spec, value, tc.expValue)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var _ = K8sClient(&MockK8sClient{})
@@ -28,10 +29,59 @@ var _ = K8sClient(&MockK8sClient{})
 type MockK8sClient struct {
 	NumOfNodes        int
 	NumOfCores        int
+	MemoryBytes       int64
+	ExtendedResources map[string]int64
+	NumOfPods         int
+	RequestedCores    float64
+	RequestedMemory   int64
+	NumOfServices     int
+	NumOfEndpoints    int
 	NumOfReplicas     int
+	// CountPods, CountRequests and CountServices mock enabling the pod,
+	// request and service informers.
+	CountPods     bool
+	CountRequests bool
+	CountServices bool
+	// WorkloadReplicas and ReadyWorkloadReplicas hold the replicas of other
+	// workloads, keyed by kind/name.
+	WorkloadReplicas      map[string]int32
+	ReadyWorkloadReplicas map[string]int32
+	// TargetPodRequests are the requests of a pod of the target.
+	TargetPodRequests v1.ResourceList
+	// TargetRequests are the summed requests of the pods of the targets.
+	TargetRequests v1.ResourceList
+	// ContainerResources holds the resources set on the containers of the
+	// target, keyed by container name.
+	ContainerResources map[string]v1.ResourceRequirements
+	// TargetHPAs are the HorizontalPodAutoscalers scaling the target, and
+	// HPAMinReplicas and HPAMaxReplicas the replicas set on them.
+	TargetHPAs     []string
+	HPAMinReplicas int32
+	HPAMaxReplicas int32
+	// PDBMinAvailable and PDBMaxUnavailable are set on the PodDisruptionBudgets
+	// of the targets.
+	PDBMinAvailable   *intstr.IntOrString
+	PDBMaxUnavailable *intstr.IntOrString
+	// Targets are the targets as kind/name, and TargetClients the clients
+	// returned for each of them by ForTarget.
+	Targets           []string
+	TargetClients     map[string]*MockK8sClient
 	ConfigMap         *v1.ConfigMap
 	FetchConfigMapFn  func(namespace, configmap string) (*v1.ConfigMap, error)
 	CreateConfigMapFn func(namespace, configmap string, params map[string]string) (*v1.ConfigMap, error)
+	UpdateConfigMapFn func(namespace, configmap string, params map[string]string) (*v1.ConfigMap, error)
+	// UpdateConfigMapDataFn mocks replacing the data of a fetched configmap.
+	UpdateConfigMapDataFn func(configMap *v1.ConfigMap, data map[string]string) (*v1.ConfigMap, error)
+
+	// TargetTopologyDomains are the topology domains of zonal targets, and
+	// TopologyDomains the status of each domain.
+	TargetTopologyDomains map[string]string
+	TopologyDomains       map[string]*ClusterStatus
+	// TopologyNodes are the numbers of nodes of each topology domain, keyed by
+	// topology label and then by domain.
+	TopologyNodes map[string]map[string]NodeCounts
+	// TargetWeights are the weights of weighted targets.
+	TargetWeights map[string]int32
 }
 
 // FetchConfigMap mocks fetching the requested configmap from the Apiserver
@@ -55,12 +105,42 @@ func (k *MockK8sClient) CreateConfigMap(namespace, configmap string, params map[
 
 // UpdateConfigMap mocks updating a configmap with given namespace, name and params
 func (k *MockK8sClient) UpdateConfigMap(namespace, configmap string, params map[string]string) (*v1.ConfigMap, error) {
+	if k.UpdateConfigMapFn != nil {
+		return k.UpdateConfigMapFn(namespace, configmap, params)
+	}
+	return nil, nil
+}
+
+// UpdateConfigMapData mocks replacing the data of a fetched configmap
+func (k *MockK8sClient) UpdateConfigMapData(configMap *v1.ConfigMap, data map[string]string) (*v1.ConfigMap, error) {
+	if k.UpdateConfigMapDataFn != nil {
+		return k.UpdateConfigMapDataFn(configMap, data)
+	}
 	return nil, nil
 }
 
 // GetClusterStatus mocks counting schedulable nodes and cores in the cluster
 func (k *MockK8sClient) GetClusterStatus() (*ClusterStatus, error) {
-	return &ClusterStatus{int32(k.NumOfNodes), int32(k.NumOfNodes), int32(k.NumOfCores), int32(k.NumOfCores)}, nil
+	return &ClusterStatus{
+		TotalNodes:                   int32(k.NumOfNodes),
+		SchedulableNodes:             int32(k.NumOfNodes),
+		TotalCores:                   int32(k.NumOfCores),
+		SchedulableCores:             int32(k.NumOfCores),
+		TotalMemory:                  k.MemoryBytes,
+		SchedulableMemory:            k.MemoryBytes,
+		TotalExtendedResources:       k.ExtendedResources,
+		SchedulableExtendedResources: k.ExtendedResources,
+		TotalPods:                    int32(k.NumOfPods),
+		RequestedCores:               k.RequestedCores,
+		RequestedMemory:              k.RequestedMemory,
+		TotalServices:                int32(k.NumOfServices),
+		TotalEndpoints:               int32(k.NumOfEndpoints),
+		PodsCounted:                  k.CountPods,
+		RequestsCounted:              k.CountRequests,
+		ServicesCounted:              k.CountServices,
+		TopologyDomains:              k.TopologyDomains,
+		TopologyNodes:                k.TopologyNodes,
+	}, nil
 }
 
 // GetNamespace mocks returning the namespace of target resource.
@@ -73,3 +153,84 @@ func (k *MockK8sClient) UpdateReplicas(expReplicas int32) error {
 	k.NumOfReplicas = int(expReplicas)
 	return nil
 }
+
+// GetReplicas mocks returning the desired or ready replicas of a workload
+func (k *MockK8sClient) GetReplicas(workload string, ready bool) (int32, error) {
+	replicas := k.WorkloadReplicas
+	if ready {
+		replicas = k.ReadyWorkloadReplicas
+	}
+	if r, ok := replicas[workload]; ok {
+		return r, nil
+	}
+	return 0, fmt.Errorf("workload %s not found", workload)
+}
+
+// GetTargetPodRequests mocks returning the requests of a pod of the target
+func (k *MockK8sClient) GetTargetPodRequests() (v1.ResourceList, error) {
+	if k.TargetPodRequests == nil {
+		return nil, fmt.Errorf("target not found")
+	}
+	return k.TargetPodRequests, nil
+}
+
+// GetTargetRequests mocks returning the summed requests of the pods of the targets
+func (k *MockK8sClient) GetTargetRequests() (v1.ResourceList, error) {
+	if !k.CountRequests {
+		return nil, fmt.Errorf("the pod requests are not counted")
+	}
+	return k.TargetRequests, nil
+}
+
+// UpdateContainerResources mocks setting the resources of a container of the targets
+func (k *MockK8sClient) UpdateContainerResources(container string, resources v1.ResourceRequirements, tolerance float64) error {
+	if k.ContainerResources == nil {
+		k.ContainerResources = map[string]v1.ResourceRequirements{}
+	}
+	k.ContainerResources[container] = resources
+	return nil
+}
+
+// GetTargetHPAs mocks returning the HorizontalPodAutoscalers scaling the targets
+func (k *MockK8sClient) GetTargetHPAs() ([]string, error) {
+	return k.TargetHPAs, nil
+}
+
+// UpdateHPAReplicas mocks updating the replicas of a HorizontalPodAutoscaler
+func (k *MockK8sClient) UpdateHPAReplicas(name string, minReplicas, maxReplicas int32) error {
+	k.HPAMinReplicas = minReplicas
+	if maxReplicas != 0 {
+		k.HPAMaxReplicas = maxReplicas
+	}
+	return nil
+}
+
+// UpdatePodDisruptionBudgets mocks creating or updating the PodDisruptionBudgets of the targets
+func (k *MockK8sClient) UpdatePodDisruptionBudgets(expReplicas int32, budget func(replicas int32) (minAvailable, maxUnavailable *intstr.IntOrString)) error {
+	k.PDBMinAvailable, k.PDBMaxUnavailable = budget(expReplicas)
+	return nil
+}
+
+// GetTargets mocks returning the targets
+func (k *MockK8sClient) GetTargets() []string {
+	return k.Targets
+}
+
+// ForTarget mocks returning a client scaling only the given target
+func (k *MockK8sClient) ForTarget(workload string) (K8sClient, error) {
+	client, ok := k.TargetClients[workload]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a target", workload)
+	}
+	return client, nil
+}
+
+// GetTargetTopologyDomains mocks returning the topology domains of zonal targets
+func (k *MockK8sClient) GetTargetTopologyDomains() map[string]string {
+	return k.TargetTopologyDomains
+}
+
+// GetTargetWeights mocks returning the weights of weighted targets
+func (k *MockK8sClient) GetTargetWeights() map[string]int32 {
+	return k.TargetWeights
+}
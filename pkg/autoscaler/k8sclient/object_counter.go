@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/golang/glog"
+)
+
+// objectCounterSyncTimeout bounds the initial listing of a counted resource, so
+// that a misconfigured resource does not block polling forever.
+const objectCounterSyncTimeout = 30 * time.Second
+
+// ObjectCounterSpec selects the objects of a resource counted for a signal.
+type ObjectCounterSpec struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	// Namespace restricts the counted objects to a namespace, all namespaces if empty.
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"labelSelector"`
+	FieldSelector string `json:"fieldSelector"`
+}
+
+func (s ObjectCounterSpec) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: s.Group, Version: s.Version, Resource: s.Resource}
+}
+
+// ObjectCounter counts the objects of arbitrary resources (e.g. Ingresses or
+// custom resources) through dynamic informers.
+type ObjectCounter interface {
+	// SyncCounters starts counting the objects selected by specs, keyed by signal
+	// name, and stops counting for signals which are no longer configured.
+	SyncCounters(specs map[string]ObjectCounterSpec) error
+	// CountObjects returns the number of objects counted for each signal.
+	CountObjects() (map[string]int64, error)
+}
+
+type objectCounter struct {
+	client   dynamic.Interface
+	counters map[string]*counter
+}
+
+type counter struct {
+	spec   ObjectCounterSpec
+	lister cache.GenericLister
+	stopCh chan struct{}
+}
+
+// NewObjectCounter gives an ObjectCounter listing objects with the given client.
+// No object is counted until SyncCounters is called.
+func NewObjectCounter(client dynamic.Interface) ObjectCounter {
+	return &objectCounter{
+		client:   client,
+		counters: make(map[string]*counter),
+	}
+}
+
+func getTrimmedDynamicClients(client dynamic.Interface, spec ObjectCounterSpec) (dynamicinformer.DynamicSharedInformerFactory, cache.SharedIndexInformer, cache.GenericLister, error) {
+	tweakListOptions := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = spec.LabelSelector
+		opts.FieldSelector = spec.FieldSelector
+	}
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, spec.Namespace, tweakListOptions)
+	informer := factory.ForResource(spec.groupVersionResource())
+	err := informer.Informer().SetTransform(func(obj any) (any, error) {
+		// Only the existence of objects matters, trim everything else.
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			trimmed := &unstructured.Unstructured{}
+			trimmed.SetAPIVersion(u.GetAPIVersion())
+			trimmed.SetKind(u.GetKind())
+			trimmed.SetName(u.GetName())
+			trimmed.SetNamespace(u.GetNamespace())
+			return trimmed, nil
+		}
+		return obj, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return factory, informer.Informer(), informer.Lister(), nil
+}
+
+func (o *objectCounter) SyncCounters(specs map[string]ObjectCounterSpec) error {
+	for name, c := range o.counters {
+		if spec, ok := specs[name]; !ok || spec != c.spec {
+			glog.V(0).Infof("Stop counting objects for signal %q", name)
+			close(c.stopCh)
+			delete(o.counters, name)
+		}
+	}
+	for name, spec := range specs {
+		if _, ok := o.counters[name]; ok {
+			continue
+		}
+		c, err := o.startCounter(spec)
+		if err != nil {
+			return fmt.Errorf("error counting objects for signal %q: %v", name, err)
+		}
+		glog.V(0).Infof("Start counting %v for signal %q", spec.groupVersionResource(), name)
+		o.counters[name] = c
+	}
+	return nil
+}
+
+func (o *objectCounter) startCounter(spec ObjectCounterSpec) (*counter, error) {
+	if spec.Version == "" || spec.Resource == "" {
+		return nil, fmt.Errorf("version and resource should be provided, got: %+v", spec)
+	}
+	if _, err := labels.Parse(spec.LabelSelector); err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %v", spec.LabelSelector, err)
+	}
+	if _, err := fields.ParseSelector(spec.FieldSelector); err != nil {
+		return nil, fmt.Errorf("invalid field selector %q: %v", spec.FieldSelector, err)
+	}
+	factory, informer, lister, err := getTrimmedDynamicClients(o.client, spec)
+	if err != nil {
+		return nil, err
+	}
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	ctx, cancel := context.WithTimeout(context.Background(), objectCounterSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		close(stopCh)
+		return nil, fmt.Errorf("timed out listing %v", spec.groupVersionResource())
+	}
+	return &counter{spec: spec, lister: lister, stopCh: stopCh}, nil
+}
+
+func (o *objectCounter) CountObjects() (map[string]int64, error) {
+	counts := make(map[string]int64, len(o.counters))
+	for name, c := range o.counters {
+		objects, err := c.lister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		counts[name] = int64(len(objects))
+	}
+	return counts, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newRoute(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetAPIVersion("gateway.networking.k8s.io/v1")
+	route.SetKind("HTTPRoute")
+	route.SetNamespace(namespace)
+	route.SetName(name)
+	route.SetLabels(labels)
+	return route
+}
+
+func TestCountObjects(t *testing.T) {
+	routes := schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{routes: "HTTPRouteList"},
+		newRoute("default", "web", map[string]string{"tier": "public"}),
+		newRoute("default", "api", map[string]string{"tier": "public"}),
+		newRoute("kube-system", "internal", nil),
+	)
+
+	testCases := []struct {
+		specs     map[string]ObjectCounterSpec
+		expError  bool
+		expCounts map[string]int64
+	}{
+		{
+			map[string]ObjectCounterSpec{},
+			false,
+			map[string]int64{},
+		},
+		{
+			map[string]ObjectCounterSpec{
+				"routes": {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
+			},
+			false,
+			map[string]int64{"routes": 3},
+		},
+		{
+			map[string]ObjectCounterSpec{
+				"routes":        {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
+				"defaultRoutes": {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes", Namespace: "default"},
+				"publicRoutes":  {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes", LabelSelector: "tier=public"},
+			},
+			false,
+			map[string]int64{"routes": 3, "defaultRoutes": 2, "publicRoutes": 2},
+		},
+		{
+			// Counters of signals which are no longer configured are stopped.
+			map[string]ObjectCounterSpec{
+				"publicRoutes": {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes", LabelSelector: "tier=public"},
+			},
+			false,
+			map[string]int64{"publicRoutes": 2},
+		},
+		{
+			map[string]ObjectCounterSpec{
+				"routes": {Group: "gateway.networking.k8s.io", Resource: "httproutes"},
+			},
+			true,
+			nil,
+		},
+		{
+			map[string]ObjectCounterSpec{
+				"routes": {Version: "v1", Resource: "httproutes", LabelSelector: "tier in (public"},
+			},
+			true,
+			nil,
+		},
+	}
+
+	counter := NewObjectCounter(client)
+	for _, tc := range testCases {
+		err := counter.SyncCounters(tc.specs)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for specs %v", tc.specs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for specs %v: %v", tc.specs, err)
+			continue
+		}
+		counts, err := counter.CountObjects()
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(counts, tc.expCounts) {
+			t.Errorf("CountObjects()=%v, want %v", counts, tc.expCounts)
+		}
+	}
+}
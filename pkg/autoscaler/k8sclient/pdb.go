@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// managedByLabel marks the objects created and reconciled by the
+	// autoscaler, other objects are never updated.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "cluster-proportional-autoscaler"
+)
+
+func (k *k8sClient) UpdatePodDisruptionBudgets(expReplicas int32, budget func(replicas int32) (minAvailable, maxUnavailable *intstr.IntOrString)) error {
+	replicas := k.scaleTargets.split(expReplicas)
+	for i, target := range k.scaleTargets.targets {
+		minAvailable, maxUnavailable := budget(replicas[i])
+		if err := k.updateTargetPodDisruptionBudget(minAvailable, maxUnavailable, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateTargetPodDisruptionBudget creates or updates the PodDisruptionBudget
+// of a target, named after it and selecting its pods.
+func (k *k8sClient) updateTargetPodDisruptionBudget(minAvailable, maxUnavailable *intstr.IntOrString, target target) error {
+	w, err := k.getWorkload(&target)
+	if err != nil {
+		return err
+	}
+	spec := policyv1.PodDisruptionBudgetSpec{
+		MinAvailable:   minAvailable,
+		MaxUnavailable: maxUnavailable,
+		Selector:       w.selector,
+	}
+	pdbs := k.clientset.PolicyV1().PodDisruptionBudgets(k.scaleTargets.namespace)
+	pdb, err := pdbs.Get(context.TODO(), target.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		pdb = &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.name,
+				Namespace: k.scaleTargets.namespace,
+				Labels:    map[string]string{managedByLabel: managedByValue},
+			},
+			Spec: spec,
+		}
+		if _, err := pdbs.Create(context.TODO(), pdb, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		glog.V(0).Infof("Created PodDisruptionBudget %v for %s/%s with minAvailable %v maxUnavailable %v",
+			target.name, target.kind, target.name, minAvailable, maxUnavailable)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if pdb.Labels[managedByLabel] != managedByValue {
+		return fmt.Errorf("PodDisruptionBudget %s exists and is not managed by the autoscaler", target.name)
+	}
+	if apiequality.Semantic.DeepEqual(pdb.Spec.MinAvailable, minAvailable) &&
+		apiequality.Semantic.DeepEqual(pdb.Spec.MaxUnavailable, maxUnavailable) &&
+		apiequality.Semantic.DeepEqual(pdb.Spec.Selector, w.selector) {
+		return nil
+	}
+	glog.V(0).Infof("PodDisruptionBudget is not as expected : updating %v from minAvailable %v maxUnavailable %v to minAvailable %v maxUnavailable %v",
+		target.name,
+		pdb.Spec.MinAvailable,
+		pdb.Spec.MaxUnavailable,
+		minAvailable,
+		maxUnavailable)
+	pdb.Spec.MinAvailable = minAvailable
+	pdb.Spec.MaxUnavailable = maxUnavailable
+	pdb.Spec.Selector = w.selector
+	_, err = pdbs.Update(context.TODO(), pdb, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUpdatePodDisruptionBudgets(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	two := intstr.FromInt32(2)
+	four := intstr.FromInt32(4)
+	managed := map[string]string{managedByLabel: managedByValue}
+
+	testCases := []struct {
+		name              string
+		target            string
+		existing          *policyv1.PodDisruptionBudget
+		minAvailable      *intstr.IntOrString
+		maxUnavailable    *intstr.IntOrString
+		expError          bool
+		expMinAvailable   *intstr.IntOrString
+		expMaxUnavailable *intstr.IntOrString
+		expSelector       *metav1.LabelSelector
+	}{
+		{
+			name:              "created for a deployment",
+			target:            "deployment/web",
+			maxUnavailable:    &four,
+			expMaxUnavailable: &four,
+			expSelector:       selector,
+		},
+		{
+			name:            "created for a replication controller",
+			target:          "replicationcontroller/web",
+			minAvailable:    &two,
+			expMinAvailable: &two,
+			expSelector:     selector,
+		},
+		{
+			name:   "updated if managed",
+			target: "deployment/web",
+			existing: &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace", Labels: managed},
+				Spec:       policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &two, Selector: selector},
+			},
+			minAvailable:    &four,
+			expMinAvailable: &four,
+			expSelector:     selector,
+		},
+		{
+			name:   "left as is if not managed",
+			target: "deployment/web",
+			existing: &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &two, Selector: selector},
+			},
+			maxUnavailable: &four,
+			expError:       true,
+		},
+		{
+			name:           "missing target",
+			target:         "deployment/other",
+			maxUnavailable: &four,
+			expError:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		objects := []runtime.Object{
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace"},
+				Spec:       appsv1.DeploymentSpec{Selector: selector},
+			},
+			&v1.ReplicationController{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace"},
+				Spec:       v1.ReplicationControllerSpec{Selector: selector.MatchLabels},
+			},
+		}
+		if tc.existing != nil {
+			objects = append(objects, tc.existing)
+		}
+		client := fake.NewSimpleClientset(objects...)
+		targets, err := getScaleTargets(tc.target, "test-namespace")
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := &k8sClient{clientset: client, scaleTargets: targets}
+		err = k.UpdatePodDisruptionBudgets(40, func(replicas int32) (*intstr.IntOrString, *intstr.IntOrString) {
+			return tc.minAvailable, tc.maxUnavailable
+		})
+		if tc.expError {
+			if err == nil {
+				t.Errorf("%s: expect error, got no error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		pdb, err := client.PolicyV1().PodDisruptionBudgets("test-namespace").Get(context.TODO(), "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(pdb.Spec.MinAvailable, tc.expMinAvailable) ||
+			!reflect.DeepEqual(pdb.Spec.MaxUnavailable, tc.expMaxUnavailable) ||
+			!reflect.DeepEqual(pdb.Spec.Selector, tc.expSelector) {
+			t.Errorf("%s: got spec %+v, want minAvailable %v maxUnavailable %v selector %v",
+				tc.name, pdb.Spec, tc.expMinAvailable, tc.expMaxUnavailable, tc.expSelector)
+		}
+		if pdb.Labels[managedByLabel] != managedByValue {
+			t.Errorf("%s: got labels %v, want the managed-by label", tc.name, pdb.Labels)
+		}
+	}
+}
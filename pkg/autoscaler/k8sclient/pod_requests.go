@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// RequestCounterOptions configures the optional pod informer used to sum the
+// resource requests of the scheduled pods.
+type RequestCounterOptions struct {
+	// ExcludeDaemonSetPods leaves out the pods controlled by a DaemonSet,
+	// which run on every node regardless of the load.
+	ExcludeDaemonSetPods bool
+	// ExcludeNamespaces leaves out the pods of these namespaces.
+	ExcludeNamespaces []string
+}
+
+// scheduledNonTerminalPodsFieldSelector filters out pods which are not bound
+// to a node yet or have run to completion.
+const scheduledNonTerminalPodsFieldSelector = nonTerminalPodsFieldSelector + ",spec.nodeName!="
+
+func getTrimmedRequestPodClients(clientset kubernetes.Interface) (informers.SharedInformerFactory, corelisters.PodLister, error) {
+	options := informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.FieldSelector = scheduledNonTerminalPodsFieldSelector
+	})
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, options)
+	podInformer := factory.Core().V1().Pods().Informer()
+	err := podInformer.SetTransform(func(obj any) (any, error) {
+		// Trimming unneeded fields to reduce memory consumption under large-scale,
+		// the effective requests are kept as the requests of a single container
+		// and the labels to select the pods of the targets.
+		if pod, ok := obj.(*v1.Pod); ok {
+			requests := podRequests(pod)
+			meta := metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				Labels:    pod.Labels,
+			}
+			if owner := metav1.GetControllerOf(pod); owner != nil {
+				meta.OwnerReferences = []metav1.OwnerReference{*owner}
+			}
+			pod.ObjectMeta = meta
+			pod.Spec = v1.PodSpec{
+				NodeName:   pod.Spec.NodeName,
+				Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: requests}}},
+			}
+			pod.Status = v1.PodStatus{
+				Phase: pod.Status.Phase,
+			}
+		}
+		return obj, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	podLister := factory.Core().V1().Pods().Lister()
+	return factory, podLister, nil
+}
+
+// podRequests returns the cpu and memory requests of a pod as accounted by the
+// scheduler: the larger of the requests of the containers, sidecars included,
+// and of any init container running alongside the sidecars started before it,
+// plus the pod overhead.
+func podRequests(pod *v1.Pod) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		var containers, sidecars, initContainers resource.Quantity
+		for _, c := range pod.Spec.Containers {
+			containers.Add(c.Resources.Requests[name])
+		}
+		for _, c := range pod.Spec.InitContainers {
+			request := c.Resources.Requests[name]
+			if c.RestartPolicy != nil && *c.RestartPolicy == v1.ContainerRestartPolicyAlways {
+				sidecars.Add(request)
+				continue
+			}
+			request.Add(sidecars)
+			if request.Cmp(initContainers) > 0 {
+				initContainers = request
+			}
+		}
+		containers.Add(sidecars)
+		if initContainers.Cmp(containers) > 0 {
+			containers = initContainers
+		}
+		containers.Add(pod.Spec.Overhead[name])
+		requests[name] = containers
+	}
+	return requests
+}
+
+// sumRequests sums the cpu and memory requests of the pods, leaving out the
+// pods excluded by the options.
+func sumRequests(pods []*v1.Pod, options *RequestCounterOptions) (cpu, memory resource.Quantity) {
+	excludedNamespaces := make(map[string]bool, len(options.ExcludeNamespaces))
+	for _, namespace := range options.ExcludeNamespaces {
+		excludedNamespaces[namespace] = true
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || isPodTerminal(pod) || excludedNamespaces[pod.Namespace] {
+			continue
+		}
+		if options.ExcludeDaemonSetPods {
+			if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+				continue
+			}
+		}
+		for _, c := range pod.Spec.Containers {
+			cpu.Add(c.Resources.Requests[v1.ResourceCPU])
+			memory.Add(c.Resources.Requests[v1.ResourceMemory])
+		}
+	}
+	return cpu, memory
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sclient
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func requests(cpu, memory string) v1.ResourceRequirements {
+	return v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		},
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	always := v1.ContainerRestartPolicyAlways
+	testCases := []struct {
+		name      string
+		spec      v1.PodSpec
+		expCPU    string
+		expMemory string
+	}{
+		{
+			"containers",
+			v1.PodSpec{
+				Containers: []v1.Container{
+					{Resources: requests("100m", "128Mi")},
+					{Resources: requests("400m", "256Mi")},
+				},
+			},
+			"500m",
+			"384Mi",
+		},
+		{
+			"init container larger than containers",
+			v1.PodSpec{
+				InitContainers: []v1.Container{{Resources: requests("1", "64Mi")}},
+				Containers:     []v1.Container{{Resources: requests("500m", "128Mi")}},
+			},
+			"1",
+			"128Mi",
+		},
+		{
+			"sidecar running alongside containers and later init containers",
+			v1.PodSpec{
+				InitContainers: []v1.Container{
+					{Resources: requests("200m", "64Mi"), RestartPolicy: &always},
+					{Resources: requests("900m", "64Mi")},
+				},
+				Containers: []v1.Container{{Resources: requests("500m", "128Mi")}},
+			},
+			"1100m",
+			"192Mi",
+		},
+		{
+			"overhead",
+			v1.PodSpec{
+				Containers: []v1.Container{{Resources: requests("250m", "128Mi")}},
+				Overhead:   requests("250m", "64Mi").Requests,
+			},
+			"500m",
+			"192Mi",
+		},
+	}
+
+	for _, tc := range testCases {
+		result := podRequests(&v1.Pod{Spec: tc.spec})
+		if cpu := result[v1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expCPU)) != 0 {
+			t.Errorf("%s: cpu=%v, want %v", tc.name, cpu.String(), tc.expCPU)
+		}
+		if memory := result[v1.ResourceMemory]; memory.Cmp(resource.MustParse(tc.expMemory)) != 0 {
+			t.Errorf("%s: memory=%v, want %v", tc.name, memory.String(), tc.expMemory)
+		}
+	}
+}
+
+func TestGetClusterStatusRequests(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	isController := true
+	daemonSetOwner := []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &isController}}
+	for _, pod := range []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("1500m", "1Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-1", Namespace: "default", OwnerReferences: daemonSetOwner},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("100m", "128Mi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("500m", "256Mi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{ // Not scheduled yet
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Resources: requests("4", "4Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		{ // Run to completion
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("4", "4Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodSucceeded},
+		},
+	} {
+		if _, err := client.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		options   *RequestCounterOptions
+		expCores  float64
+		expMemory int64
+	}{
+		{nil, 0, 0},
+		{&RequestCounterOptions{}, 2.1, (1024 + 128 + 256) << 20},
+		{&RequestCounterOptions{ExcludeDaemonSetPods: true}, 2, (1024 + 256) << 20},
+		{&RequestCounterOptions{ExcludeDaemonSetPods: true, ExcludeNamespaces: []string{"kube-system"}}, 1.5, 1024 << 20},
+	}
+
+	for _, tc := range testCases {
+		k8sClient, err := NewK8sClient(client, "test-namespace", "deployment/test-target", "", "", nil, nil, tc.options, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		status, err := k8sClient.GetClusterStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.RequestedCores != tc.expCores {
+			t.Errorf("status.RequestedCores=%v, want %v for options %+v", status.RequestedCores, tc.expCores, tc.options)
+		}
+		if status.RequestedMemory != tc.expMemory {
+			t.Errorf("status.RequestedMemory=%v, want %v for options %+v", status.RequestedMemory, tc.expMemory, tc.options)
+		}
+	}
+}
+
+func TestGetTargetRequests(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	placeholder := map[string]string{"app": "placeholder"}
+	for _, deployment := range []*appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: placeholder}},
+		},
+		{ // Selects the same pods
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder-alias", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: placeholder}},
+		},
+	} {
+		if _, err := client.AppsV1().Deployments(deployment.Namespace).Create(context.Background(), deployment, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, pod := range []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder-1", Namespace: "default", Labels: placeholder},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("1", "1Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder-2", Namespace: "default", Labels: placeholder},
+			Spec:       v1.PodSpec{NodeName: "node-2", Containers: []v1.Container{{Resources: requests("1", "1Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{ // Not scheduled yet
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder-3", Namespace: "default", Labels: placeholder},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Resources: requests("1", "1Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		{ // Another workload
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("4", "4Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		{ // Another namespace
+			ObjectMeta: metav1.ObjectMeta{Name: "placeholder-1", Namespace: "other", Labels: placeholder},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: requests("4", "4Gi")}}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+	} {
+		if _, err := client.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		target    string
+		options   *RequestCounterOptions
+		expError  bool
+		expCPU    string
+		expMemory string
+	}{
+		{"deployment/placeholder", nil, true, "", ""},
+		{"deployment/placeholder", &RequestCounterOptions{}, false, "2", "2Gi"},
+		{"deployment/placeholder,deployment/placeholder-alias", &RequestCounterOptions{}, false, "2", "2Gi"},
+		{"deployment/placeholder", &RequestCounterOptions{ExcludeNamespaces: []string{"default"}}, false, "0", "0"},
+		{"deployment/missing", &RequestCounterOptions{}, true, "", ""},
+	}
+
+	for _, tc := range testCases {
+		k8sClient, err := NewK8sClient(client, "default", tc.target, "", "", nil, nil, tc.options, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := k8sClient.GetTargetRequests()
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for %s with options %+v", tc.target, tc.options)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", tc.target, err)
+			continue
+		}
+		if cpu := result[v1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expCPU)) != 0 {
+			t.Errorf("%s: cpu=%v, want %v", tc.target, cpu.String(), tc.expCPU)
+		}
+		if memory := result[v1.ResourceMemory]; memory.Cmp(resource.MustParse(tc.expMemory)) != 0 {
+			t.Errorf("%s: memory=%v, want %v", tc.target, memory.String(), tc.expMemory)
+		}
+	}
+}
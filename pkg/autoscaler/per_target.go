@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/controller/plugin"
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// perTargetKey holds the params of each target, keyed by kind/name, as the
+	// only entry of the ConfigMap.
+	perTargetKey = "targets"
+)
+
+// parsePerTargetParams parses the params of each target, keyed by kind/name in
+// lower case, into the ConfigMap data of its control mode.
+func parsePerTargetParams(data string) (map[string]map[string]string, error) {
+	var raw map[string]map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("could not parse per-target params (%s)", err)
+	}
+	params := make(map[string]map[string]string, len(raw))
+	for target, modes := range raw {
+		key := strings.ToLower(target)
+		if _, ok := params[key]; ok {
+			return nil, fmt.Errorf("duplicated params for target %s", target)
+		}
+		params[key] = make(map[string]string, len(modes))
+		for mode, p := range modes {
+			params[key][mode] = string(p)
+		}
+	}
+	return params, nil
+}
+
+// targetConfigMap returns the ConfigMap a target's controller is synced with.
+// Its version is derived from the params of the target only, so that changing
+// the params of a target leaves the controllers of the other targets as is.
+func targetConfigMap(configMap *v1.ConfigMap, data map[string]string) *v1.ConfigMap {
+	modes := make([]string, 0, len(data))
+	for mode := range data {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	h := fnv.New64a()
+	for _, mode := range modes {
+		h.Write([]byte(mode))
+		h.Write([]byte{0})
+		h.Write([]byte(data[mode]))
+		h.Write([]byte{0})
+	}
+	target := configMap.DeepCopy()
+	target.Data = data
+	target.ObjectMeta.ResourceVersion = strconv.FormatUint(h.Sum64(), 16)
+	return target
+}
+
+// ensureTargetControllers ensures a controller for each target from the
+// per-target params of the ConfigMap, only rebuilding the ones whose params
+// changed.
+func (s *AutoScaler) ensureTargetControllers(configMap *v1.ConfigMap, data string) error {
+	if s.targetControllers != nil && configMap.ObjectMeta.ResourceVersion == s.targetParamsVersion {
+		return nil
+	}
+	if s.hpaName != "" || s.outputConfigMap != "" {
+		return fmt.Errorf("per-target params are not supported along with --hpa or --output-configmap")
+	}
+	// Each target is scaled to the replicas of its own controller, which
+	// leaves nothing to split by weight.
+	if s.k8sClient.GetTargetWeights() != nil {
+		return fmt.Errorf("per-target params are not supported along with weighted targets")
+	}
+	if len(configMap.Data) != 1 {
+		return fmt.Errorf("invalid configMap format, expected only the %s entry along with per-target params, got: %v", perTargetKey, configMap.Data)
+	}
+	params, err := parsePerTargetParams(data)
+	if err != nil {
+		return err
+	}
+	targets := s.k8sClient.GetTargets()
+	known := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		key := strings.ToLower(target)
+		if _, ok := params[key]; !ok {
+			return fmt.Errorf("no params for target %s", target)
+		}
+		known[key] = true
+	}
+	for key := range params {
+		if !known[key] {
+			return fmt.Errorf("params given for %s which is not a target", key)
+		}
+	}
+	// Ensure the declared signals are counted before the controllers refer to them.
+	if err := s.syncSignals(configMap); err != nil {
+		return fmt.Errorf("error syncing signals: %v", err)
+	}
+
+	controllers := make(map[string]controller.Controller, len(targets))
+	for _, target := range targets {
+		key := strings.ToLower(target)
+		targetConfig := targetConfigMap(configMap, params[key])
+		cont := s.targetControllers[key]
+		if cont == nil || cont.GetParamsVersion() != targetConfig.ObjectMeta.ResourceVersion {
+			client, err := s.k8sClient.ForTarget(target)
+			if err != nil {
+				return err
+			}
+			cont, err = plugin.EnsureController(cont, targetConfig, client)
+			if err != nil || cont == nil {
+				return fmt.Errorf("error ensuring controller of %s: %v", target, err)
+			}
+		}
+		controllers[key] = cont
+	}
+	s.targetControllers = controllers
+	s.targetParamsVersion = configMap.ObjectMeta.ResourceVersion
+	s.controller = nil
+	return nil
+}
+
+// scaleEachTarget scales each target on its own, with its own controller if
+// given per-target params, and from the nodes of its topology domain if zonal.
+// A failing target does not prevent the others from being scaled.
+func (s *AutoScaler) scaleEachTarget(clusterStatus *k8sclient.ClusterStatus) error {
+	var errs []error
+	domains := s.k8sClient.GetTargetTopologyDomains()
+	for _, target := range s.k8sClient.GetTargets() {
+		client, err := s.k8sClient.ForTarget(target)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		cont := s.controller
+		if s.targetControllers != nil {
+			cont = s.targetControllers[strings.ToLower(target)]
+		}
+		status := clusterStatus
+		if domain, ok := domains[target]; ok {
+			status = clusterStatus.ForTopologyDomain(domain)
+			glog.V(4).Infof("Scaling %s from topology domain %s: schedulable nodes %d, schedulable cores %d", target, domain, status.SchedulableNodes, status.SchedulableCores)
+		} else {
+			glog.V(4).Infof("Scaling %s", target)
+		}
+		if err := s.scale(cont, client, status); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", target, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestParsePerTargetParams(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expError  bool
+		expParams map[string]map[string]string
+	}{
+		{
+			`{
+			  "deployment/web": { "linear": {"nodesPerReplica":10} },
+			  "Deployment/API": { "ladder": {"nodesToReplicas":[[1,1]]} }
+			}`,
+			false,
+			map[string]map[string]string{
+				"deployment/web": {"linear": `{"nodesPerReplica":10}`},
+				"deployment/api": {"ladder": `{"nodesToReplicas":[[1,1]]}`},
+			},
+		},
+		{
+			`{ "deployment/web": { "linear": {"nodesPerReplica":10} }, "deployment/WEB": { "linear": {"nodesPerReplica":5} } }`,
+			true,
+			nil,
+		},
+		{
+			`{ "deployment/web": "linear" }`,
+			true,
+			nil,
+		},
+		{
+			`[]`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		params, err := parsePerTargetParams(tc.data)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for params %s", tc.data)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for params %s: %v", tc.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(params, tc.expParams) {
+			t.Errorf("parsePerTargetParams()=%v, want %v", params, tc.expParams)
+		}
+	}
+}
+
+func TestParseSignals_PerTarget(t *testing.T) {
+	configMap := &v1.ConfigMap{Data: map[string]string{
+		perTargetKey: `{
+		  "deployment/web": { "linear": { "nodesPerReplica": 10 } },
+		  "deployment/api": { "expression": {
+		    "expression": "qps / 100",
+		    "signals": { "qps": { "prometheus": { "query": "sum(rate(requests[5m]))" } } }
+		  } }
+		}`,
+	}}
+	signals, err := parseSignals(configMap)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expQueries := map[string]string{"qps": "sum(rate(requests[5m]))"}
+	if !reflect.DeepEqual(signals.prometheusQueries, expQueries) {
+		t.Errorf("Expected prometheus queries %v, got %v", expQueries, signals.prometheusQueries)
+	}
+}
+
+func TestPollAPIServer_PerTarget(t *testing.T) {
+	configMap := &v1.ConfigMap{Data: map[string]string{
+		perTargetKey: `{
+		  "deployment/web": { "linear": { "nodesPerReplica": 10 } },
+		  "deployment/api": { "linear": { "nodesPerReplica": 5 } }
+		}`,
+	}}
+	configMap.ObjectMeta.ResourceVersion = "1"
+	web := &k8sclient.MockK8sClient{}
+	api := &k8sclient.MockK8sClient{}
+	mockK8s := &k8sclient.MockK8sClient{
+		NumOfNodes: 50,
+		ConfigMap:  configMap,
+		Targets:    []string{"deployment/web", "deployment/api"},
+		TargetClients: map[string]*k8sclient.MockK8sClient{
+			"deployment/web": web,
+			"deployment/api": api,
+		},
+	}
+	autoScaler := &AutoScaler{k8sClient: mockK8s}
+	if err := autoScaler.pollAPIServer(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if web.NumOfReplicas != 5 || api.NumOfReplicas != 10 || mockK8s.NumOfReplicas != 0 {
+		t.Errorf("Expected 5 replicas for web and 10 for api, got %d and %d", web.NumOfReplicas, api.NumOfReplicas)
+	}
+
+	// Changing the params of a target leaves the controllers of the other
+	// targets as is.
+	webController := autoScaler.targetControllers["deployment/web"]
+	webVersion := webController.GetParamsVersion()
+	apiVersion := autoScaler.targetControllers["deployment/api"].GetParamsVersion()
+	configMap.Data[perTargetKey] = `{
+	  "deployment/web": { "linear": { "nodesPerReplica": 10 } },
+	  "deployment/api": { "linear": { "nodesPerReplica": 25 } }
+	}`
+	configMap.ObjectMeta.ResourceVersion = "2"
+	if err := autoScaler.pollAPIServer(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if web.NumOfReplicas != 5 || api.NumOfReplicas != 2 {
+		t.Errorf("Expected 5 replicas for web and 2 for api, got %d and %d", web.NumOfReplicas, api.NumOfReplicas)
+	}
+	if autoScaler.targetControllers["deployment/web"] != webController || webController.GetParamsVersion() != webVersion {
+		t.Errorf("Expected the controller of web to be left as is")
+	}
+	if autoScaler.targetControllers["deployment/api"].GetParamsVersion() == apiVersion {
+		t.Errorf("Expected the params version of api to change")
+	}
+
+	// A failing target does not prevent the others from being scaled.
+	api.TargetHPAs = []string{"api"}
+	mockK8s.NumOfNodes = 100
+	if err := autoScaler.pollAPIServer(); err == nil {
+		t.Errorf("Expect error, got no error for a target scaled by a HorizontalPodAutoscaler")
+	}
+	if web.NumOfReplicas != 10 || api.NumOfReplicas != 2 {
+		t.Errorf("Expected 10 replicas for web and 2 for api, got %d and %d", web.NumOfReplicas, api.NumOfReplicas)
+	}
+
+	// Each target should have params, and only the targets.
+	for _, data := range []string{
+		`{ "deployment/web": { "linear": { "nodesPerReplica": 10 } } }`,
+		`{
+		  "deployment/web": { "linear": { "nodesPerReplica": 10 } },
+		  "deployment/api": { "linear": { "nodesPerReplica": 5 } },
+		  "deployment/other": { "linear": { "nodesPerReplica": 5 } }
+		}`,
+	} {
+		configMap.Data[perTargetKey] = data
+		configMap.ObjectMeta.ResourceVersion += "1"
+		if err := autoScaler.pollAPIServer(); err == nil {
+			t.Errorf("Expect error, got no error for params %s", data)
+		}
+	}
+
+	// Weighted targets are rejected rather than each scaled to its replicas.
+	configMap.Data[perTargetKey] = `{
+	  "deployment/web": { "linear": { "nodesPerReplica": 10 } },
+	  "deployment/api": { "linear": { "nodesPerReplica": 5 } }
+	}`
+	configMap.ObjectMeta.ResourceVersion += "1"
+	mockK8s.TargetWeights = map[string]int32{"deployment/web": 3, "deployment/api": 1}
+	if err := autoScaler.pollAPIServer(); err == nil {
+		t.Errorf("Expect error, got no error for weighted targets")
+	}
+}
+
+func TestPollAPIServer_Zonal(t *testing.T) {
+	configMap := &v1.ConfigMap{Data: map[string]string{
+		"linear": `{ "nodesPerReplica": 10, "min": 1 }`,
+	}}
+	configMap.ObjectMeta.ResourceVersion = "1"
+	dnsA := &k8sclient.MockK8sClient{}
+	dnsB := &k8sclient.MockK8sClient{}
+	mockK8s := &k8sclient.MockK8sClient{
+		NumOfNodes: 90,
+		ConfigMap:  configMap,
+		Targets:    []string{"deployment/dns-a", "deployment/dns-b", "deployment/dns-c"},
+		TargetClients: map[string]*k8sclient.MockK8sClient{
+			"deployment/dns-a": dnsA,
+			"deployment/dns-b": dnsB,
+			"deployment/dns-c": {},
+		},
+		TargetTopologyDomains: map[string]string{
+			"deployment/dns-a": "us-east-1a",
+			"deployment/dns-b": "us-east-1b",
+			"deployment/dns-c": "us-east-1c",
+		},
+		TopologyDomains: map[string]*k8sclient.ClusterStatus{
+			"us-east-1a": {TotalNodes: 60, SchedulableNodes: 60},
+			"us-east-1b": {TotalNodes: 30, SchedulableNodes: 30},
+		},
+	}
+	autoScaler := &AutoScaler{k8sClient: mockK8s}
+	if err := autoScaler.pollAPIServer(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Each target is scaled from the nodes of its own domain, a domain
+	// without nodes yields the minimum
+	dnsC := mockK8s.TargetClients["deployment/dns-c"]
+	if dnsA.NumOfReplicas != 6 || dnsB.NumOfReplicas != 3 || dnsC.NumOfReplicas != 1 || mockK8s.NumOfReplicas != 0 {
+		t.Errorf("Expected 6, 3 and 1 replicas, got %d, %d and %d", dnsA.NumOfReplicas, dnsB.NumOfReplicas, dnsC.NumOfReplicas)
+	}
+}
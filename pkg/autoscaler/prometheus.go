@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// PrometheusSignalProvider is the name of the provider of the prometheus signals
+	PrometheusSignalProvider = "prometheus"
+
+	prometheusQueryPath = "/api/v1/query"
+)
+
+// prometheusSignalSpec declares a signal whose value is the result of a PromQL
+// query, which should evaluate to a scalar or to a single-sample vector.
+type prometheusSignalSpec struct {
+	Query string `json:"query"`
+}
+
+// prometheusSignalProvider provides the results of PromQL queries through the
+// Prometheus HTTP API as signals.
+type prometheusSignalProvider struct {
+	address        string
+	authHeaderFile string
+	client         *http.Client
+	// queries holds the PromQL queries keyed by signal name.
+	queries map[string]string
+}
+
+func newPrometheusSignalProvider(address string, timeout time.Duration, authHeaderFile string) *prometheusSignalProvider {
+	return &prometheusSignalProvider{
+		address:        strings.TrimSuffix(address, "/"),
+		authHeaderFile: authHeaderFile,
+		client:         &http.Client{Timeout: timeout},
+	}
+}
+
+// prometheusResponse is the envelope of the responses of the Prometheus HTTP API.
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusSample is a sample of an instant vector.
+type prometheusSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// GetSignals runs every query. Signals whose query failed are left out, so that
+// they may fall back to other signals.
+func (p *prometheusSignalProvider) GetSignals() (map[string]float64, error) {
+	signals := make(map[string]float64, len(p.queries))
+	var errs []error
+	for name, query := range p.queries {
+		value, err := p.query(query)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error querying signal %q: %v", name, err))
+			continue
+		}
+		signals[name] = value
+	}
+	return signals, utilerrors.NewAggregate(errs)
+}
+
+func (p *prometheusSignalProvider) query(query string) (float64, error) {
+	req, err := http.NewRequest(http.MethodGet, p.address+prometheusQueryPath+"?"+url.Values{"query": {query}}.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if p.authHeaderFile != "" {
+		// Read the file on each query so that rotated credentials are picked up.
+		authHeader, err := os.ReadFile(p.authHeaderFile)
+		if err != nil {
+			return 0, fmt.Errorf("could not read auth header file: %v", err)
+		}
+		req.Header.Set("Authorization", strings.TrimSpace(string(authHeader)))
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var result prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("could not decode response with status %s: %v", resp.Status, err)
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("query failed with status %s: %s", resp.Status, result.Error)
+	}
+	return parsePrometheusResult(result.Data.ResultType, result.Data.Result)
+}
+
+// parsePrometheusResult extracts the value of a scalar or of a vector holding a
+// single sample.
+func parsePrometheusResult(resultType string, result json.RawMessage) (float64, error) {
+	var value []interface{}
+	switch resultType {
+	case "scalar":
+		if err := json.Unmarshal(result, &value); err != nil {
+			return 0, fmt.Errorf("invalid scalar %s: %v", result, err)
+		}
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(result, &samples); err != nil {
+			return 0, fmt.Errorf("invalid vector %s: %v", result, err)
+		}
+		if len(samples) != 1 {
+			return 0, fmt.Errorf("expected a single sample, got %d", len(samples))
+		}
+		value = samples[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q, expected scalar or vector", resultType)
+	}
+	// Values are [ <unix_time>, "<value>" ] pairs.
+	if len(value) != 2 {
+		return 0, fmt.Errorf("invalid value %v", value)
+	}
+	raw, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid value %v", value)
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q: %v", raw, err)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("invalid value %q", raw)
+	}
+	return f, nil
+}
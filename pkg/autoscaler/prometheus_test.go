@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPrometheusSignalProvider(t *testing.T) {
+	responses := map[string]string{
+		"scalar":        `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"42"]}}`,
+		"vector":        `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"1234.5"]}]}}`,
+		"empty":         `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"multiple":      `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"a":"1"},"value":[1700000000,"1"]},{"metric":{"a":"2"},"value":[1700000000,"2"]}]}}`,
+		"matrix":        `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		"nan":           `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"NaN"]}}`,
+		"invalid query": `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != prometheusQueryPath {
+			http.NotFound(w, r)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"status":"error","error":"unauthorized"}`)
+			return
+		}
+		response, ok := responses[r.URL.Query().Get("query")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+
+	authHeaderFile := filepath.Join(t.TempDir(), "auth")
+	if err := os.WriteFile(authHeaderFile, []byte("Bearer secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		authHeaderFile string
+		queries        map[string]string
+		expError       bool
+		expSignals     map[string]float64
+	}{
+		{
+			authHeaderFile,
+			map[string]string{"a": "scalar", "b": "vector"},
+			false,
+			map[string]float64{"a": 42, "b": 1234.5},
+		},
+		{
+			// Failing queries are left out of the signals.
+			authHeaderFile,
+			map[string]string{"a": "scalar", "b": "empty", "c": "multiple", "d": "matrix", "e": "nan", "f": "invalid query"},
+			true,
+			map[string]float64{"a": 42},
+		},
+		{
+			"",
+			map[string]string{"a": "scalar"},
+			true,
+			map[string]float64{},
+		},
+		{
+			filepath.Join(t.TempDir(), "missing"),
+			map[string]string{"a": "scalar"},
+			true,
+			map[string]float64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		provider := newPrometheusSignalProvider(server.URL+"/", time.Second, tc.authHeaderFile)
+		provider.queries = tc.queries
+		signals, err := provider.GetSignals()
+		if err != nil && !tc.expError {
+			t.Errorf("Unexpected error for queries %v: %v", tc.queries, err)
+		} else if err == nil && tc.expError {
+			t.Errorf("Expect error, got no error for queries %v", tc.queries)
+		}
+		if !reflect.DeepEqual(signals, tc.expSignals) {
+			t.Errorf("GetSignals()=%v, want %v", signals, tc.expSignals)
+		}
+	}
+}
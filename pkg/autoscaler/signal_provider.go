@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"fmt"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// ClusterSignalProvider is the name of the provider of the cluster status signals
+	ClusterSignalProvider = "cluster"
+	// ObjectsSignalProvider is the name of the provider of the object counts
+	ObjectsSignalProvider = "objects"
+	// MetricsSignalProvider is the name of the provider of the custom and external metrics
+	MetricsSignalProvider = "metrics"
+)
+
+// SignalProvider provides named numeric scaling inputs.
+type SignalProvider interface {
+	// GetSignals returns the current values of the provided signals, keyed by name.
+	GetSignals() (map[string]float64, error)
+}
+
+type namedSignalProvider struct {
+	name     string
+	provider SignalProvider
+}
+
+// signalRegistry holds the signal providers in registration order. The zero
+// value is an empty registry ready to use.
+type signalRegistry struct {
+	providers []namedSignalProvider
+}
+
+// register adds a provider to the registry under a unique name.
+func (r *signalRegistry) register(name string, provider SignalProvider) error {
+	for _, p := range r.providers {
+		if p.name == name {
+			return fmt.Errorf("signal provider %q is already registered", name)
+		}
+	}
+	r.providers = append(r.providers, namedSignalProvider{name, provider})
+	return nil
+}
+
+// collect queries every provider. The signals a provider fails to get are left
+// out so that they may fall back to others, and a signal provided more than
+// once keeps the value of the provider registered first.
+func (r *signalRegistry) collect() map[string]float64 {
+	signals := make(map[string]float64)
+	for _, p := range r.providers {
+		// A provider may return the signals it could get along with an error.
+		values, err := p.provider.GetSignals()
+		if err != nil {
+			glog.Warningf("Error getting signals from provider %q: %v", p.name, err)
+		}
+		for name, value := range values {
+			if _, ok := signals[name]; !ok {
+				signals[name] = value
+			}
+		}
+	}
+	return signals
+}
+
+// applyFallbacks fills in the signals which are unavailable with the first
+// available signal of their fallback chain.
+func applyFallbacks(signals map[string]float64, fallbacks map[string][]string) {
+	for name, chain := range fallbacks {
+		if _, ok := signals[name]; ok {
+			continue
+		}
+		for _, fallback := range chain {
+			if value, ok := signals[fallback]; ok {
+				glog.V(1).Infof("Signal %q is unavailable, falling back to %q", name, fallback)
+				signals[name] = value
+				break
+			}
+		}
+	}
+}
+
+// clusterSignalProvider provides the node, core, memory, pod, request, service
+// and endpoint counts of the cluster status as signals. The status is the one
+// fetched by the current poll, rather than fetched again.
+type clusterSignalProvider struct {
+	status *k8sclient.ClusterStatus
+}
+
+func (p *clusterSignalProvider) GetSignals() (map[string]float64, error) {
+	if p.status == nil {
+		return nil, fmt.Errorf("no cluster status polled yet")
+	}
+	return clusterStatusSignals(p.status), nil
+}
+
+// clusterStatusSignals returns the signals of a cluster status, keyed by name.
+// The pod, request, service and endpoint counts are left out unless counted, so
+// that inputs reading them fail rather than scale on 0.
+func clusterStatusSignals(status *k8sclient.ClusterStatus) map[string]float64 {
+	signals := map[string]float64{
+		"nodes":             float64(status.TotalNodes),
+		"schedulableNodes":  float64(status.SchedulableNodes),
+		"cores":             float64(status.TotalCores),
+		"schedulableCores":  float64(status.SchedulableCores),
+		"memory":            float64(status.TotalMemory),
+		"schedulableMemory": float64(status.SchedulableMemory),
+	}
+	if status.PodsCounted {
+		signals["pods"] = float64(status.TotalPods)
+	}
+	if status.RequestsCounted {
+		signals["requestedCores"] = status.RequestedCores
+		signals["requestedMemory"] = float64(status.RequestedMemory)
+	}
+	if status.ServicesCounted {
+		signals["services"] = float64(status.TotalServices)
+		signals["endpoints"] = float64(status.TotalEndpoints)
+	}
+	return signals
+}
+
+// isClusterSignal returns whether the name is that of a signal of the cluster
+// status, which declared signals may not override.
+func isClusterSignal(name string) bool {
+	counted := &k8sclient.ClusterStatus{PodsCounted: true, RequestsCounted: true, ServicesCounted: true}
+	for builtin := range clusterStatusSignals(counted) {
+		if strings.EqualFold(name, builtin) {
+			return true
+		}
+	}
+	return false
+}
+
+// objectSignalProvider provides the object counts of an ObjectCounter as signals.
+type objectSignalProvider struct {
+	objectCounter k8sclient.ObjectCounter
+}
+
+func (p *objectSignalProvider) GetSignals() (map[string]float64, error) {
+	counts, err := p.objectCounter.CountObjects()
+	if err != nil {
+		return nil, err
+	}
+	signals := make(map[string]float64, len(counts))
+	for name, count := range counts {
+		signals[name] = float64(count)
+	}
+	return signals, nil
+}
+
+// metricSignalSpec selects either a custom or an external metric.
+type metricSignalSpec struct {
+	custom   *k8sclient.CustomMetricSpec
+	external *k8sclient.ExternalMetricSpec
+}
+
+// metricSignalProvider provides custom and external metrics as signals.
+type metricSignalProvider struct {
+	metricsClient k8sclient.MetricsClient
+	// specs holds the metrics keyed by signal name.
+	specs map[string]metricSignalSpec
+}
+
+// GetSignals reads every metric. Signals whose metric could not be read are
+// left out, so that they may fall back to other signals.
+func (p *metricSignalProvider) GetSignals() (map[string]float64, error) {
+	signals := make(map[string]float64, len(p.specs))
+	var errs []error
+	for name, spec := range p.specs {
+		var value float64
+		var err error
+		if spec.custom != nil {
+			value, err = p.metricsClient.GetCustomMetric(*spec.custom)
+		} else {
+			value, err = p.metricsClient.GetExternalMetric(*spec.external)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading metric of signal %q: %v", name, err))
+			continue
+		}
+		signals[name] = value
+	}
+	return signals, utilerrors.NewAggregate(errs)
+}
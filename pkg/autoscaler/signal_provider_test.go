@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+type fakeSignalProvider struct {
+	signals map[string]float64
+	err     error
+}
+
+func (p *fakeSignalProvider) GetSignals() (map[string]float64, error) {
+	return p.signals, p.err
+}
+
+func TestSignalRegistry(t *testing.T) {
+	var registry signalRegistry
+	if err := registry.register("metrics", &fakeSignalProvider{err: errors.New("backend unavailable")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.register("cluster", &fakeSignalProvider{signals: map[string]float64{"nodes": 10, "cores": 40}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.register("objects", &fakeSignalProvider{signals: map[string]float64{"routes": 25, "nodes": 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.register("objects", &fakeSignalProvider{}); err == nil {
+		t.Errorf("Expect error registering a provider twice, got no error")
+	}
+
+	testCases := []struct {
+		fallbacks  map[string][]string
+		expSignals map[string]float64
+	}{
+		{
+			nil,
+			map[string]float64{"nodes": 10, "cores": 40, "routes": 25},
+		},
+		{
+			// The failing provider falls back to the first available signal.
+			map[string][]string{"qps": {"ingresses", "routes", "nodes"}},
+			map[string]float64{"nodes": 10, "cores": 40, "routes": 25, "qps": 25},
+		},
+		{
+			// Available signals do not fall back.
+			map[string][]string{"routes": {"nodes"}},
+			map[string]float64{"nodes": 10, "cores": 40, "routes": 25},
+		},
+		{
+			// Signals stay unavailable when their whole chain is.
+			map[string][]string{"qps": {"ingresses"}},
+			map[string]float64{"nodes": 10, "cores": 40, "routes": 25},
+		},
+	}
+
+	for _, tc := range testCases {
+		signals := registry.collect()
+		applyFallbacks(signals, tc.fallbacks)
+		if !reflect.DeepEqual(signals, tc.expSignals) {
+			t.Errorf("signals=%v, want %v for fallbacks %v", signals, tc.expSignals, tc.fallbacks)
+		}
+	}
+}
+
+func TestClusterSignalProvider(t *testing.T) {
+	provider := &clusterSignalProvider{}
+	if _, err := provider.GetSignals(); err == nil {
+		t.Errorf("Expect error before any cluster status is polled, got no error")
+	}
+
+	testCases := []struct {
+		status     k8sclient.ClusterStatus
+		expSignals []string
+		noSignals  []string
+	}{
+		{
+			// Counts which are not counted are left out rather than 0.
+			k8sclient.ClusterStatus{TotalNodes: 4, RequestedCores: 800},
+			[]string{"nodes", "cores"},
+			[]string{"pods", "requestedCores", "requestedMemory", "services", "endpoints"},
+		},
+		{
+			k8sclient.ClusterStatus{RequestsCounted: true, RequestedCores: 800},
+			[]string{"requestedCores", "requestedMemory"},
+			[]string{"pods", "services", "endpoints"},
+		},
+		{
+			k8sclient.ClusterStatus{PodsCounted: true, ServicesCounted: true},
+			[]string{"pods", "services", "endpoints"},
+			[]string{"requestedCores", "requestedMemory"},
+		},
+	}
+
+	for _, tc := range testCases {
+		provider.status = &tc.status
+		signals, err := provider.GetSignals()
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+		for _, name := range tc.expSignals {
+			if _, ok := signals[name]; !ok {
+				t.Errorf("Expect signal %q in %v", name, signals)
+			}
+		}
+		for _, name := range tc.noSignals {
+			if _, ok := signals[name]; ok {
+				t.Errorf("Unexpected signal %q in %v", name, signals)
+			}
+		}
+	}
+
+	// The names of the counts are built-in regardless of the counting.
+	if !isClusterSignal("RequestedCores") {
+		t.Errorf("Expect requestedCores to be a built-in signal")
+	}
+}
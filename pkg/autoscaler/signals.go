@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+// signalsParams are the "signals" and "fallbacks" sections which the params of
+// any control mode may carry to declare additional named scaling inputs, and
+// the signals to fall back to when they are unavailable.
+type signalsParams struct {
+	Signals   map[string]signalSpec `json:"signals"`
+	Fallbacks map[string][]string   `json:"fallbacks"`
+}
+
+// signalSpec declares how the value of a signal is obtained, either by counting
+// objects, by querying Prometheus or by reading a custom or external metric.
+type signalSpec struct {
+	k8sclient.ObjectCounterSpec
+	Prometheus     *prometheusSignalSpec         `json:"prometheus"`
+	CustomMetric   *k8sclient.CustomMetricSpec   `json:"customMetric"`
+	ExternalMetric *k8sclient.ExternalMetricSpec `json:"externalMetric"`
+}
+
+// signalsConfig holds the signals declared in the ConfigMap, by source.
+type signalsConfig struct {
+	objectCounters    map[string]k8sclient.ObjectCounterSpec
+	prometheusQueries map[string]string
+	metrics           map[string]metricSignalSpec
+	fallbacks         map[string][]string
+}
+
+// parseSignals parses the signals declared in the params of the ConfigMap.
+func parseSignals(configMap *v1.ConfigMap) (*signalsConfig, error) {
+	signals := &signalsConfig{
+		objectCounters:    make(map[string]k8sclient.ObjectCounterSpec),
+		prometheusQueries: make(map[string]string),
+		metrics:           make(map[string]metricSignalSpec),
+		fallbacks:         make(map[string][]string),
+	}
+	// Per-target params are flattened into the params of each target, keyed
+	// by the target and its control mode.
+	params := make(map[string]string, len(configMap.Data))
+	for mode, data := range configMap.Data {
+		if mode != perTargetKey {
+			params[mode] = data
+			continue
+		}
+		perTarget, err := parsePerTargetParams(data)
+		if err != nil {
+			return nil, err
+		}
+		for target, modes := range perTarget {
+			for mode, data := range modes {
+				params[target+" "+mode] = data
+			}
+		}
+	}
+	modes := make([]string, 0, len(params))
+	for mode := range params {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	for _, mode := range modes {
+		var p signalsParams
+		if err := json.Unmarshal([]byte(params[mode]), &p); err != nil {
+			return nil, fmt.Errorf("could not parse signals of %s params (%s)", mode, err)
+		}
+		for name, spec := range p.Signals {
+			if name == "" {
+				return nil, fmt.Errorf("invalid empty signal name in %s params", mode)
+			}
+			if isClusterSignal(name) {
+				return nil, fmt.Errorf("signal %q in %s params collides with a built-in signal", name, mode)
+			}
+			sources := 0
+			for _, set := range []bool{spec.Prometheus != nil, spec.CustomMetric != nil, spec.ExternalMetric != nil} {
+				if set {
+					sources++
+				}
+			}
+			if sources > 1 || (sources == 1 && spec.ObjectCounterSpec != (k8sclient.ObjectCounterSpec{})) {
+				return nil, fmt.Errorf("signal %q should have a single source in %s params", name, mode)
+			}
+			switch {
+			case spec.Prometheus != nil:
+				if spec.Prometheus.Query == "" {
+					return nil, fmt.Errorf("invalid empty prometheus query for signal %q in %s params", name, mode)
+				}
+				signals.prometheusQueries[name] = spec.Prometheus.Query
+			case spec.CustomMetric != nil, spec.ExternalMetric != nil:
+				signals.metrics[name] = metricSignalSpec{custom: spec.CustomMetric, external: spec.ExternalMetric}
+			default:
+				signals.objectCounters[name] = spec.ObjectCounterSpec
+			}
+		}
+		for name, chain := range p.Fallbacks {
+			for _, fallback := range chain {
+				if fallback == "" || fallback == name {
+					return nil, fmt.Errorf("invalid fallback %q for signal %q in %s params", fallback, name, mode)
+				}
+			}
+			signals.fallbacks[name] = chain
+		}
+	}
+	return signals, nil
+}
+
+// syncSignals ensures the signals declared in the ConfigMap are counted or queried.
+func (s *AutoScaler) syncSignals(configMap *v1.ConfigMap) error {
+	signals, err := parseSignals(configMap)
+	if err != nil {
+		return err
+	}
+	if s.objectCounter == nil {
+		if len(signals.objectCounters) != 0 {
+			return fmt.Errorf("signals counting objects are not supported without an object counter")
+		}
+	} else if err := s.objectCounter.SyncCounters(signals.objectCounters); err != nil {
+		return err
+	}
+	if s.prometheus == nil {
+		if len(signals.prometheusQueries) != 0 {
+			return fmt.Errorf("prometheus signals require --prometheus-address to be set")
+		}
+	} else {
+		s.prometheus.queries = signals.prometheusQueries
+	}
+	if s.metrics == nil {
+		if len(signals.metrics) != 0 {
+			return fmt.Errorf("signals reading metrics are not supported without a metrics client")
+		}
+	} else {
+		s.metrics.specs = signals.metrics
+	}
+	s.signalFallbacks = signals.fallbacks
+	return nil
+}
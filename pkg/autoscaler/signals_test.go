@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/cluster-proportional-autoscaler/pkg/autoscaler/k8sclient"
+)
+
+func TestParseSignals(t *testing.T) {
+	testCases := []struct {
+		data       string
+		expError   bool
+		expSignals *signalsConfig
+	}{
+		{
+			`{ "nodesPerReplica": 1 }`,
+			false,
+			&signalsConfig{
+				objectCounters:    map[string]k8sclient.ObjectCounterSpec{},
+				prometheusQueries: map[string]string{},
+				metrics:           map[string]metricSignalSpec{},
+				fallbacks:         map[string][]string{},
+			},
+		},
+		{
+			`{
+			  "nodesPerReplica": 1,
+			  "routesPerReplica": 50,
+			  "signals": {
+			    "routes": {
+			      "group": "gateway.networking.k8s.io",
+			      "version": "v1",
+			      "resource": "httproutes",
+			      "labelSelector": "tier=public"
+			    },
+			    "namespaces": {
+			      "version": "v1",
+			      "resource": "namespaces"
+			    },
+			    "qps": {
+			      "prometheus": { "query": "sum(rate(nginx_ingress_controller_requests[5m]))" }
+			    },
+			    "ingressQps": {
+			      "customMetric": {
+			        "apiVersion": "networking.k8s.io/v1",
+			        "kind": "Ingress",
+			        "name": "main",
+			        "namespace": "web",
+			        "metric": "requests_per_second"
+			      }
+			    },
+			    "orders": {
+			      "externalMetric": { "metric": "queue_messages_ready", "selector": "queue=orders" }
+			    }
+			  },
+			  "fallbacks": {
+			    "routes": [ "namespaces", "nodes" ]
+			  }
+			}`,
+			false,
+			&signalsConfig{
+				objectCounters: map[string]k8sclient.ObjectCounterSpec{
+					"routes":     {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes", LabelSelector: "tier=public"},
+					"namespaces": {Version: "v1", Resource: "namespaces"},
+				},
+				prometheusQueries: map[string]string{
+					"qps": "sum(rate(nginx_ingress_controller_requests[5m]))",
+				},
+				metrics: map[string]metricSignalSpec{
+					"ingressQps": {custom: &k8sclient.CustomMetricSpec{
+						APIVersion: "networking.k8s.io/v1",
+						Kind:       "Ingress",
+						Name:       "main",
+						Namespace:  "web",
+						Metric:     "requests_per_second",
+					}},
+					"orders": {external: &k8sclient.ExternalMetricSpec{Metric: "queue_messages_ready", Selector: "queue=orders"}},
+				},
+				fallbacks: map[string][]string{
+					"routes": {"namespaces", "nodes"},
+				},
+			},
+		},
+		{
+			`{ "signals": { "": { "version": "v1", "resource": "namespaces" } } }`,
+			true,
+			nil,
+		},
+		{
+			`{ "signals": [] }`,
+			true,
+			nil,
+		},
+		{ // A signal may not override a built-in signal
+			`{ "signals": { "Pods": { "version": "v1", "resource": "pods" } } }`,
+			true,
+			nil,
+		},
+		{ // A signal should have a single source
+			`{ "signals": { "qps": { "version": "v1", "resource": "namespaces", "prometheus": { "query": "up" } } } }`,
+			true,
+			nil,
+		},
+		{
+			`{ "signals": { "qps": { "prometheus": { "query": "up" }, "externalMetric": { "metric": "qps" } } } }`,
+			true,
+			nil,
+		},
+		{
+			`{ "signals": { "qps": { "prometheus": { "query": "" } } } }`,
+			true,
+			nil,
+		},
+		{ // A signal cannot fall back to itself
+			`{ "fallbacks": { "routes": [ "routes" ] } }`,
+			true,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		configMap := &v1.ConfigMap{Data: map[string]string{"linear": tc.data}}
+		signals, err := parseSignals(configMap)
+		if tc.expError {
+			if err == nil {
+				t.Errorf("Expect error, got no error for params %s", tc.data)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for params %s: %v", tc.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(signals, tc.expSignals) {
+			t.Errorf("parseSignals()=%v, want %v", signals, tc.expSignals)
+		}
+	}
+}